@@ -0,0 +1,301 @@
+package promptory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// batchPromptRequest identifies one prompt within a batch request, mirroring
+// PromptRef's ID-or-name addressing.
+type batchPromptRequest struct {
+	ID          string `json:"id,omitempty"`
+	Org         string `json:"org,omitempty"`
+	App         string `json:"app,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+type batchRequestBody struct {
+	Prompts []batchPromptRequest `json:"prompts"`
+}
+
+// batchResponseEntry is one result in a batch response, aligned by index
+// to the request's Prompts. Error is set (and Prompt left nil) if the
+// server couldn't resolve that particular entry, without failing the rest
+// of the batch.
+type batchResponseEntry struct {
+	Prompt *Prompt `json:"prompt"`
+	ETag   string  `json:"etag"`
+	Error  string  `json:"error,omitempty"`
+}
+
+type batchResponseBody struct {
+	Results []batchResponseEntry `json:"results"`
+}
+
+// GetMany fetches multiple prompts by ID in as few round trips as
+// possible: already-cached, fresh IDs are served from the cache, and the
+// rest are fetched with a single POST to the batch endpoint (falling back
+// to bounded-concurrency parallel Get-equivalent calls if the server
+// doesn't support it; see fetchBatch). Coalescing happens per ID, via the
+// same c.inflight group plain Get uses (see fetchManyCoalesced): a cold ID
+// that another concurrent GetMany, PrefetchByName, or Get call is already
+// fetching is simply waited on rather than fetched again, so two GetMany
+// calls for overlapping-but-different ID sets still share the overlap
+// instead of each issuing their own batch request for it.
+//
+// An ID the server can't resolve is simply absent from the returned map
+// rather than causing GetMany to fail; GetMany only returns an error for a
+// failure that prevented fetching anything, such as a network error.
+func (c *Client) GetMany(ctx context.Context, ids []string) (map[string]*Prompt, error) {
+	result := make(map[string]*Prompt, len(ids))
+	var refs []PromptRef
+
+	for _, id := range ids {
+		cacheKey := "id:" + id
+		if entry, fresh := c.cache.Get(cacheKey); entry != nil && fresh {
+			result[id] = entry.Value
+			continue
+		}
+		refs = append(refs, PromptRef{ID: id})
+	}
+	if len(refs) == 0 {
+		return result, nil
+	}
+
+	fetched, err := c.fetchManyCoalesced(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range refs {
+		if prompt, ok := fetched["id:"+ref.ID]; ok {
+			result[ref.ID] = prompt
+		}
+	}
+	return result, nil
+}
+
+// PrefetchByName warms the cache for each ref in refs with as few round
+// trips as possible, coalescing per cache key exactly like GetMany (see
+// fetchManyCoalesced), and falling back to Prefetch's bounded-concurrency
+// parallel fetches if the server doesn't support the batch route (see
+// fetchBatch). Like GetMany, and unlike Prefetch, a ref the server can't
+// resolve is simply left uncached rather than failing the whole call;
+// PrefetchByName only returns an error for a failure that prevented ANY
+// prefetching.
+func (c *Client) PrefetchByName(ctx context.Context, refs []PromptRef) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	_, err := c.fetchManyCoalesced(ctx, refs)
+	return err
+}
+
+// fetchManyCoalesced fetches refs with per-cache-key singleflight
+// coalescing: a ref whose cache key already has a fetch in flight -
+// started by a concurrent Get, GetMany, or PrefetchByName call - is simply
+// waited on via c.inflight rather than fetched again. Every ref this call
+// doesn't already share with an in-flight fetch is "owned" by this call
+// and retrieved together in a single fetchBatch POST, so a thundering herd
+// of goroutines warming disjoint-but-overlapping ID sets at startup still
+// issues one network call per distinct cold key, not one per caller.
+func (c *Client) fetchManyCoalesced(ctx context.Context, refs []PromptRef) (map[string]*Prompt, error) {
+	type owned struct {
+		ref  PromptRef
+		key  string
+		call *inflightCall
+	}
+	var mine []owned
+	var waitKeys []string
+	var waitCalls []*inflightCall
+
+	c.inflight.mu.Lock()
+	for _, ref := range refs {
+		cacheKey, _ := ref.cacheKeyAndPath(c.apiVersion())
+		if call, ok := c.inflight.calls[cacheKey]; ok {
+			waitKeys = append(waitKeys, cacheKey)
+			waitCalls = append(waitCalls, call)
+			continue
+		}
+		call := &inflightCall{}
+		call.wg.Add(1)
+		c.inflight.calls[cacheKey] = call
+		mine = append(mine, owned{ref: ref, key: cacheKey, call: call})
+	}
+	c.inflight.mu.Unlock()
+
+	result := make(map[string]*Prompt, len(refs))
+
+	if len(mine) > 0 {
+		ownedRefs := make([]PromptRef, len(mine))
+		for i, o := range mine {
+			ownedRefs[i] = o.ref
+		}
+		fetched, err := c.fetchBatch(ctx, ownedRefs)
+
+		c.inflight.mu.Lock()
+		for _, o := range mine {
+			delete(c.inflight.calls, o.key)
+		}
+		c.inflight.mu.Unlock()
+
+		for _, o := range mine {
+			if err == nil {
+				o.call.value = fetched[o.key]
+			} else {
+				o.call.err = err
+			}
+			o.call.wg.Done()
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range mine {
+			if o.call.value != nil {
+				result[o.key] = o.call.value
+			}
+		}
+	}
+
+	for i, call := range waitCalls {
+		call.wg.Wait()
+		if call.err != nil {
+			return nil, call.err
+		}
+		if call.value != nil {
+			result[waitKeys[i]] = call.value
+		}
+	}
+
+	return result, nil
+}
+
+// fetchBatch issues a single POST to the batch endpoint for refs,
+// returning each successfully resolved prompt keyed by the cache key
+// PromptRef.cacheKeyAndPath would produce, and populating the cache with
+// its ETag exactly as doFetch does for a single-prompt fetch. When
+// c.verifyKeys is set, every entry is verified exactly as doFetch verifies
+// a single-prompt fetch (a nil Signature counts as a failure) before it's
+// cached or returned; a verification failure fails the whole batch, since
+// there's no single caller to report a partial failure to. If the server
+// doesn't have the batch route (404), it falls back to fetchParallel. An
+// entry the server reports as not found is omitted from the result rather
+// than failing the batch.
+func (c *Client) fetchBatch(ctx context.Context, refs []PromptRef) (map[string]*Prompt, error) {
+	reqEntries := make([]batchPromptRequest, len(refs))
+	for i, ref := range refs {
+		reqEntries[i] = batchPromptRequest{
+			ID:          ref.ID,
+			Org:         ref.Org,
+			App:         ref.App,
+			Name:        ref.Name,
+			Environment: ref.Environment,
+		}
+	}
+	bodyBytes, err := json.Marshal(batchRequestBody{Prompts: reqEntries})
+	if err != nil {
+		return nil, &PromptoryError{Message: "failed to marshal batch request: " + err.Error()}
+	}
+
+	path := fmt.Sprintf("/api/%s/prompts/batch", c.apiVersion())
+	resp, err := c.doRequest(ctx, EndpointBatch, http.MethodPost, path, bodyBytes)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := c.handleError(resp)
+		if isNotFound(apiErr) {
+			return c.fetchParallel(ctx, refs)
+		}
+		return nil, apiErr
+	}
+
+	var decoded batchResponseBody
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, &PromptoryError{Message: "failed to decode batch response: " + err.Error()}
+	}
+	if len(decoded.Results) != len(refs) {
+		return nil, &PromptoryError{Message: fmt.Sprintf("batch response had %d results, want %d", len(decoded.Results), len(refs))}
+	}
+
+	results := make(map[string]*Prompt, len(refs))
+	for i, entry := range decoded.Results {
+		if entry.Error != "" || entry.Prompt == nil {
+			continue
+		}
+		if err := c.verifyFetchedPrompt(entry.Prompt); err != nil {
+			return nil, err
+		}
+		cacheKey, _ := refs[i].cacheKeyAndPath(c.apiVersion())
+		c.cache.Set(cacheKey, entry.Prompt, entry.ETag)
+		results[cacheKey] = entry.Prompt
+	}
+	return results, nil
+}
+
+// fetchParallel fetches each ref individually with bounded concurrency
+// (ClientOptions.MaxConcurrent), the batch route's fallback when the
+// server doesn't support it. It calls doFetch directly rather than going
+// through fetchWithCache: fetchParallel only ever runs for refs whose
+// cache keys fetchManyCoalesced has already claimed in c.inflight, so
+// routing back through c.inflight here would just wait on the very call
+// this goroutine is part of. ETag-aware caching still behaves identically
+// to Get/GetByName; only the redundant per-key coalescing layer is
+// skipped, since fetchManyCoalesced already provides it one level up.
+func (c *Client) fetchParallel(ctx context.Context, refs []PromptRef) (map[string]*Prompt, error) {
+	sem := make(chan struct{}, c.maxConcurrent)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var once sync.Once
+	results := make(map[string]*Prompt, len(refs))
+	var firstErr error
+
+	for _, ref := range refs {
+		ref := ref
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			cacheKey, path := ref.cacheKeyAndPath(c.apiVersion())
+			if cached, fresh := c.cache.Get(cacheKey); cached != nil && fresh {
+				mu.Lock()
+				results[cacheKey] = cached.Value
+				mu.Unlock()
+				return
+			}
+
+			c.trackActiveKey(cacheKey, path)
+			cached, _ := c.cache.Get(cacheKey)
+			prompt, err := c.doFetch(ctx, path, cacheKey, cached, attrsFromCacheKey(cacheKey))
+			if isNotFound(err) {
+				return
+			}
+			if err != nil {
+				once.Do(func() { firstErr = err })
+				return
+			}
+			mu.Lock()
+			results[cacheKey] = prompt
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results, firstErr
+}
+
+// isNotFound reports whether err is a PromptoryError for a 404 response,
+// the signal fetchBatch uses to fall back to fetchParallel.
+func isNotFound(err error) bool {
+	var pe *PromptoryError
+	return errors.As(err, &pe) && pe.StatusCode == http.StatusNotFound
+}
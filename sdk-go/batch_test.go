@@ -0,0 +1,257 @@
+package promptory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetMany_UsesBatchEndpointAndCachesResults(t *testing.T) {
+	var batchCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/prompts/batch" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		atomic.AddInt32(&batchCalls, 1)
+
+		var req batchRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		results := make([]batchResponseEntry, len(req.Prompts))
+		for i, p := range req.Prompts {
+			if p.ID == "missing" {
+				results[i] = batchResponseEntry{Error: "not found"}
+				continue
+			}
+			prompt := samplePrompt
+			prompt.ID = p.ID
+			results[i] = batchResponseEntry{Prompt: &prompt, ETag: "v1"}
+		}
+		json.NewEncoder(w).Encode(batchResponseBody{Results: results})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetMany(context.Background(), []string{"p1", "p2", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d prompts, want 2 (missing should be omitted): %v", len(got), got)
+	}
+	if got["p1"] == nil || got["p1"].ID != "p1" {
+		t.Errorf("got[p1] = %+v", got["p1"])
+	}
+	if _, ok := got["missing"]; ok {
+		t.Error("expected missing to be absent from the result")
+	}
+	if atomic.LoadInt32(&batchCalls) != 1 {
+		t.Errorf("batch endpoint called %d times, want 1", batchCalls)
+	}
+
+	if stats := client.CacheStats(); stats.Size != 2 {
+		t.Errorf("CacheStats().Size = %d, want 2", stats.Size)
+	}
+}
+
+func TestGetMany_ServesFreshIDsFromCacheWithoutABatchCall(t *testing.T) {
+	var batchCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&batchCalls, 1)
+		json.NewEncoder(w).Encode(batchResponseBody{})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.cache.Set("id:p1", &Prompt{ID: "p1"}, "v1")
+
+	got, err := client.GetMany(context.Background(), []string{"p1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got["p1"].ID != "p1" {
+		t.Errorf("got = %+v", got)
+	}
+	if atomic.LoadInt32(&batchCalls) != 0 {
+		t.Errorf("batch endpoint called %d times, want 0 (all IDs were cached)", batchCalls)
+	}
+}
+
+func TestGetMany_FallsBackToParallelFetchesOn404(t *testing.T) {
+	var individualCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/prompts/batch" {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"message": "no batch route"},
+			})
+			return
+		}
+		atomic.AddInt32(&individualCalls, 1)
+		prompt := samplePrompt
+		json.NewEncoder(w).Encode(prompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetMany(context.Background(), []string{samplePrompt.ID, "other-id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d prompts, want 2", len(got))
+	}
+	if atomic.LoadInt32(&individualCalls) != 2 {
+		t.Errorf("individual GETs = %d, want 2", individualCalls)
+	}
+}
+
+func TestGetMany_CoalescesConcurrentCalls(t *testing.T) {
+	var batchCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&batchCalls, 1)
+
+		var req batchRequestBody
+		json.NewDecoder(r.Body).Decode(&req)
+		results := make([]batchResponseEntry, len(req.Prompts))
+		for i, p := range req.Prompts {
+			prompt := samplePrompt
+			prompt.ID = p.ID
+			results[i] = batchResponseEntry{Prompt: &prompt, ETag: "v1"}
+		}
+		json.NewEncoder(w).Encode(batchResponseBody{Results: results})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 10
+	done := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := client.GetMany(context.Background(), []string{"p1", "p2"})
+			done <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&batchCalls); got != 1 {
+		t.Errorf("batch endpoint called %d times, want 1 (coalesced)", got)
+	}
+}
+
+func TestGetMany_CoalescesOverlappingIDSets(t *testing.T) {
+	var batchCalls int32
+	var totalIDs int32
+	release := make(chan struct{})
+	firstBatchStarted := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&batchCalls, 1) == 1 {
+			close(firstBatchStarted)
+			<-release
+		}
+
+		var req batchRequestBody
+		json.NewDecoder(r.Body).Decode(&req)
+		atomic.AddInt32(&totalIDs, int32(len(req.Prompts)))
+		results := make([]batchResponseEntry, len(req.Prompts))
+		for i, p := range req.Prompts {
+			prompt := samplePrompt
+			prompt.ID = p.ID
+			results[i] = batchResponseEntry{Prompt: &prompt, ETag: "v1"}
+		}
+		json.NewEncoder(w).Encode(batchResponseBody{Results: results})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := client.GetMany(context.Background(), []string{"a", "b"})
+		done <- err
+	}()
+	<-firstBatchStarted
+
+	go func() {
+		_, err := client.GetMany(context.Background(), []string{"a", "c"})
+		done <- err
+	}()
+	// Give the second call a moment to reach fetchManyCoalesced and join
+	// "a"'s in-flight call before the first batch is allowed to complete.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&batchCalls); got != 2 {
+		t.Errorf("batch endpoint called %d times, want 2 (one per call's owned IDs)", got)
+	}
+	if got := atomic.LoadInt32(&totalIDs); got != 3 {
+		t.Errorf("server saw %d total IDs across both batch calls, want 3 ([a,b] + [c]: \"a\" should be coalesced into the first call, not fetched twice)", got)
+	}
+}
+
+func TestPrefetchByName_PopulatesCacheViaBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req batchRequestBody
+		json.NewDecoder(r.Body).Decode(&req)
+		results := make([]batchResponseEntry, len(req.Prompts))
+		for i, p := range req.Prompts {
+			prompt := samplePrompt
+			prompt.Org, prompt.App, prompt.Name = p.Org, p.App, p.Name
+			results[i] = batchResponseEntry{Prompt: &prompt, ETag: "v1"}
+		}
+		json.NewEncoder(w).Encode(batchResponseBody{Results: results})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.PrefetchByName(context.Background(), []PromptRef{
+		{Org: "myorg", App: "myapp", Name: "greeting"},
+		{Org: "myorg", App: "myapp", Name: "farewell"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats := client.CacheStats(); stats.Size != 2 {
+		t.Errorf("CacheStats().Size = %d, want 2", stats.Size)
+	}
+}
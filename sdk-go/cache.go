@@ -1,4 +1,4 @@
-package promptdis
+package promptory
 
 import (
 	"strings"
@@ -6,13 +6,64 @@ import (
 	"time"
 )
 
-// CacheStats reports the current state of the LRU cache.
+// CacheStats reports the current state of a Cache.
 type CacheStats struct {
 	Size    int           `json:"size"`
 	MaxSize int           `json:"max_size"`
 	TTL     time.Duration `json:"ttl"`
 }
 
+// CacheEntry is a single cached prompt together with its ETag and expiry,
+// as returned by Cache.Get.
+type CacheEntry struct {
+	Value     *Prompt
+	ETag      string
+	ExpiresAt time.Time
+}
+
+// Cache is the interface Client uses to store and retrieve prompts.
+// ClientOptions.Cache lets callers plug in a shared, out-of-process
+// implementation (e.g. promptory/cache/redis) so a fleet of stateless
+// workers can share a single warm cache and coordinate invalidation; the
+// default, used when ClientOptions.Cache is nil, is the in-process LRU
+// cache below (also importable standalone via promptory/cache/memory).
+type Cache interface {
+	// Get returns (entry, true) if the entry exists and has not expired,
+	// (entry, false) if expired but present (stale), or (nil, false) if
+	// not found.
+	Get(key string) (entry *CacheEntry, fresh bool)
+
+	// Set adds or updates an entry in the cache.
+	Set(key string, value *Prompt, etag string)
+
+	// RefreshTTL resets the expiry time for an existing entry (e.g. after
+	// a 304 response). It is a no-op if the key is not present.
+	RefreshTTL(key string)
+
+	// Invalidate removes a specific cache entry. Returns true if the
+	// entry existed.
+	Invalidate(key string) bool
+
+	// InvalidateByPrefix removes all entries whose keys start with
+	// prefix. Returns the count of entries removed.
+	InvalidateByPrefix(prefix string) int
+
+	// Clear removes all entries from the cache.
+	Clear()
+
+	// Stats returns the current cache statistics.
+	Stats() CacheStats
+}
+
+// NewMemoryCache creates the default in-process Cache: a thread-safe LRU
+// cache with TTL support. It is exported so it can be constructed
+// explicitly (e.g. to wrap it, or to use it outside a Client), and is
+// re-exported by promptory/cache/memory for callers who'd rather not
+// import the core package just for this.
+func NewMemoryCache(maxSize int, ttl time.Duration) Cache {
+	return newLRUCache(maxSize, ttl)
+}
+
 // cacheEntry is a node in the doubly-linked list used by lruCache.
 type cacheEntry struct {
 	key       string
@@ -23,8 +74,9 @@ type cacheEntry struct {
 	next      *cacheEntry
 }
 
-// lruCache is a thread-safe LRU cache with TTL support.
-// It uses a doubly-linked list for O(1) eviction and a map for O(1) lookups.
+// lruCache is the default Cache implementation: a thread-safe LRU cache
+// with TTL support. It uses a doubly-linked list for O(1) eviction and a
+// map for O(1) lookups.
 type lruCache struct {
 	mu      sync.RWMutex
 	entries map[string]*cacheEntry
@@ -32,8 +84,17 @@ type lruCache struct {
 	tail    *cacheEntry // least recently used (eviction candidate)
 	maxSize int
 	ttl     time.Duration
+
+	// onEvict, if set, is called once per entry evicted to stay within
+	// maxSize, synchronously from within Set's critical section, so it
+	// must be fast and must not call back into this Cache. Wired up by
+	// NewClient from ClientOptions.Observer; nil by default, including
+	// for a Cache constructed directly via NewMemoryCache.
+	onEvict func()
 }
 
+var _ Cache = (*lruCache)(nil)
+
 // newLRUCache creates a new LRU cache with the given max size and TTL.
 func newLRUCache(maxSize int, ttl time.Duration) *lruCache {
 	return &lruCache{
@@ -43,10 +104,7 @@ func newLRUCache(maxSize int, ttl time.Duration) *lruCache {
 	}
 }
 
-// get retrieves a cache entry. Returns (entry, true) if the entry exists
-// and has not expired, (entry, false) if expired but present (stale),
-// or (nil, false) if not found.
-func (c *lruCache) get(key string) (entry *cacheEntry, fresh bool) {
+func (c *lruCache) Get(key string) (entry *CacheEntry, fresh bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -57,14 +115,11 @@ func (c *lruCache) get(key string) (entry *cacheEntry, fresh bool) {
 
 	c.moveToFront(e)
 
-	if time.Now().Before(e.expiresAt) {
-		return e, true
-	}
-	return e, false
+	entry = &CacheEntry{Value: e.value, ETag: e.etag, ExpiresAt: e.expiresAt}
+	return entry, time.Now().Before(e.expiresAt)
 }
 
-// set adds or updates an entry in the cache with the given TTL.
-func (c *lruCache) set(key string, value *Prompt, etag string) {
+func (c *lruCache) Set(key string, value *Prompt, etag string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -90,8 +145,7 @@ func (c *lruCache) set(key string, value *Prompt, etag string) {
 	}
 }
 
-// refreshTTL resets the expiry time for an existing entry (e.g., after a 304 response).
-func (c *lruCache) refreshTTL(key string) {
+func (c *lruCache) RefreshTTL(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -101,8 +155,7 @@ func (c *lruCache) refreshTTL(key string) {
 	}
 }
 
-// invalidate removes a specific cache entry. Returns true if the entry existed.
-func (c *lruCache) invalidate(key string) bool {
+func (c *lruCache) Invalidate(key string) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -115,9 +168,7 @@ func (c *lruCache) invalidate(key string) bool {
 	return true
 }
 
-// invalidateByPrefix removes all entries whose keys start with prefix.
-// Returns the count of entries removed.
-func (c *lruCache) invalidateByPrefix(prefix string) int {
+func (c *lruCache) InvalidateByPrefix(prefix string) int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -135,8 +186,7 @@ func (c *lruCache) invalidateByPrefix(prefix string) int {
 	return len(toDelete)
 }
 
-// clear removes all entries from the cache.
-func (c *lruCache) clear() {
+func (c *lruCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -145,8 +195,7 @@ func (c *lruCache) clear() {
 	c.tail = nil
 }
 
-// stats returns the current cache statistics.
-func (c *lruCache) stats() CacheStats {
+func (c *lruCache) Stats() CacheStats {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
@@ -200,4 +249,7 @@ func (c *lruCache) evictLRU() {
 	}
 	delete(c.entries, c.tail.key)
 	c.remove(c.tail)
+	if c.onEvict != nil {
+		c.onEvict()
+	}
 }
@@ -0,0 +1,17 @@
+// Package memory re-exports promptory's built-in in-process Cache so
+// callers can construct it without depending on the core package for
+// anything else (e.g. when wiring up ClientOptions.Cache alongside other
+// Cache implementations such as promptory/cache/redis).
+package memory
+
+import (
+	"time"
+
+	promptory "github.com/winzeler/promptory/sdk-go"
+)
+
+// New creates an in-process LRU Cache with TTL support, identical to the
+// cache Client uses by default when ClientOptions.Cache is left unset.
+func New(maxSize int, ttl time.Duration) promptory.Cache {
+	return promptory.NewMemoryCache(maxSize, ttl)
+}
@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	promptory "github.com/winzeler/promptory/sdk-go"
+)
+
+func TestNew_GetSet(t *testing.T) {
+	c := New(10, time.Minute)
+	c.Set("key1", &promptory.Prompt{ID: "1"}, "etag1")
+
+	entry, fresh := c.Get("key1")
+	if entry == nil || !fresh {
+		t.Fatal("expected fresh entry")
+	}
+	if entry.Value.ID != "1" {
+		t.Errorf("ID = %q, want %q", entry.Value.ID, "1")
+	}
+}
+
+func TestNew_ImplementsCache(t *testing.T) {
+	var _ promptory.Cache = New(10, time.Minute)
+}
@@ -0,0 +1,148 @@
+// Package redis provides a promptory.Cache implementation backed by Redis,
+// letting a fleet of stateless workers share a single warm cache and
+// coordinate invalidation out-of-process instead of each holding its own
+// in-process LRU.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	promptory "github.com/winzeler/promptory/sdk-go"
+)
+
+// Cache is a promptory.Cache backed by Redis. Each entry is stored as a
+// single serialized value (Prompt + ETag + expiry) under a namespaced key;
+// TTL is enforced Redis-side via EXPIRE, so a cache miss and a TTL expiry
+// look identical from Get's perspective (key absent).
+type Cache struct {
+	rdb       *goredis.Client
+	namespace string
+	ttl       time.Duration
+}
+
+var _ promptory.Cache = (*Cache)(nil)
+
+// New creates a Cache backed by rdb. Keys are stored under
+// "<namespace>:<key>"; namespace defaults to "promptory" if empty. ttl is
+// the duration applied via Redis EXPIRE on every Set and defaults to 60s.
+func New(rdb *goredis.Client, namespace string, ttl time.Duration) *Cache {
+	if namespace == "" {
+		namespace = "promptory"
+	}
+	if ttl <= 0 {
+		ttl = 60 * time.Second
+	}
+	return &Cache{rdb: rdb, namespace: namespace, ttl: ttl}
+}
+
+// storedEntry is the wire format written under each Redis key.
+type storedEntry struct {
+	Prompt    *promptory.Prompt `json:"prompt"`
+	ETag      string            `json:"etag"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+func (c *Cache) redisKey(key string) string {
+	return c.namespace + ":" + key
+}
+
+func (c *Cache) Get(key string) (*promptory.CacheEntry, bool) {
+	raw, err := c.rdb.Get(context.Background(), c.redisKey(key)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	var e storedEntry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return nil, false
+	}
+	entry := &promptory.CacheEntry{Value: e.Prompt, ETag: e.ETag, ExpiresAt: e.ExpiresAt}
+	return entry, time.Now().Before(e.ExpiresAt)
+}
+
+func (c *Cache) Set(key string, value *promptory.Prompt, etag string) {
+	e := storedEntry{Prompt: value, ETag: etag, ExpiresAt: time.Now().Add(c.ttl)}
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	c.rdb.Set(context.Background(), c.redisKey(key), raw, c.ttl)
+}
+
+func (c *Cache) RefreshTTL(key string) {
+	ctx := context.Background()
+	rk := c.redisKey(key)
+
+	raw, err := c.rdb.Get(ctx, rk).Bytes()
+	if err != nil {
+		return
+	}
+	var e storedEntry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return
+	}
+	e.ExpiresAt = time.Now().Add(c.ttl)
+	raw, err = json.Marshal(e)
+	if err != nil {
+		return
+	}
+	c.rdb.Set(ctx, rk, raw, c.ttl)
+}
+
+func (c *Cache) Invalidate(key string) bool {
+	n, err := c.rdb.Del(context.Background(), c.redisKey(key)).Result()
+	return err == nil && n > 0
+}
+
+// InvalidateByPrefix removes all entries whose keys start with prefix,
+// using SCAN (rather than KEYS) so it doesn't block the server on a large
+// keyspace.
+func (c *Cache) InvalidateByPrefix(prefix string) int {
+	ctx := context.Background()
+	removed := 0
+	var cursor uint64
+	for {
+		keys, next, err := c.rdb.Scan(ctx, cursor, c.redisKey(prefix)+"*", 100).Result()
+		if err != nil {
+			return removed
+		}
+		if len(keys) > 0 {
+			if n, delErr := c.rdb.Del(ctx, keys...).Result(); delErr == nil {
+				removed += int(n)
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			return removed
+		}
+	}
+}
+
+func (c *Cache) Clear() {
+	c.InvalidateByPrefix("")
+}
+
+// Stats returns best-effort cache statistics. Computing Size walks the
+// namespace's keyspace via SCAN, since Redis has no O(1) way to count keys
+// matching a pattern; avoid calling it on a hot path. MaxSize is always 0
+// since Redis has no configured capacity in this package.
+func (c *Cache) Stats() promptory.CacheStats {
+	ctx := context.Background()
+	size := 0
+	var cursor uint64
+	for {
+		keys, next, err := c.rdb.Scan(ctx, cursor, c.redisKey("")+"*", 100).Result()
+		if err != nil {
+			break
+		}
+		size += len(keys)
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return promptory.CacheStats{Size: size, TTL: c.ttl}
+}
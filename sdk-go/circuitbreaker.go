@@ -0,0 +1,185 @@
+package promptory
+
+import (
+	"sync"
+	"time"
+)
+
+// Well-known endpoint keys used to scope circuit breakers and HealthStats
+// lookups. Get/GetByName/Prefetch/background revalidation all share
+// EndpointGet since they all ultimately call doFetch for the same
+// underlying route.
+const (
+	EndpointGet    = "prompts.get"
+	EndpointRender = "prompts.render"
+	EndpointWatch  = "prompts.watch"
+	EndpointEvents = "events.subscribe"
+	EndpointBatch  = "prompts.batch"
+)
+
+// BreakerState is the state of a per-endpoint circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed is the normal state: requests are allowed through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen means recent consecutive failures tripped the breaker;
+	// requests are short-circuited with ErrCircuitOpen until the cooldown
+	// elapses.
+	BreakerOpen
+	// BreakerHalfOpen means the cooldown has elapsed and a single probe
+	// request is being allowed through to test whether the endpoint has
+	// recovered.
+	BreakerHalfOpen
+)
+
+// String returns a lowercase, hyphenated name for s (e.g. "half-open"),
+// matching how HealthStats is typically logged or printed.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// HealthStats reports the circuit breaker state for a single endpoint,
+// analogous to CacheStats for the cache and LimiterStats for the rate
+// limiter.
+type HealthStats struct {
+	Endpoint            string
+	State               BreakerState
+	ConsecutiveFailures int
+}
+
+// circuitOpenError is returned when a request is short-circuited by an open
+// circuit breaker. It's a dedicated type rather than a *PromptoryError with
+// StatusCode 0, so errors.Is(err, ErrCircuitOpen) can't collide with the
+// other zero-status PromptoryErrors (e.g. validation errors) that already
+// exist in this package.
+type circuitOpenError struct {
+	endpoint string
+}
+
+func (e *circuitOpenError) Error() string {
+	return "promptory: circuit breaker open for " + e.endpoint
+}
+
+// Is supports errors.Is matching against ErrCircuitOpen regardless of which
+// endpoint tripped the breaker.
+func (e *circuitOpenError) Is(target error) bool {
+	_, ok := target.(*circuitOpenError)
+	return ok
+}
+
+// ErrCircuitOpen indicates a request was short-circuited because its
+// endpoint's circuit breaker is open. Use errors.Is to detect it.
+var ErrCircuitOpen = &circuitOpenError{}
+
+// circuitBreaker trips after consecutiveFailures reaches threshold,
+// short-circuiting requests until cooldown elapses, then allows exactly one
+// probe request through (half-open) to test recovery.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+
+	state               BreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+	probing             bool
+}
+
+// allow reports whether a request may proceed, transitioning open -> half-open
+// once cooldown has elapsed.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerClosed:
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default: // BreakerOpen
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	}
+}
+
+// recordSuccess closes the breaker and resets its failure streak.
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.consecutiveFailures = 0
+	b.probing = false
+}
+
+// recordFailure advances the failure streak, tripping the breaker once
+// threshold is reached. A failed half-open probe reopens the breaker
+// immediately and restarts its cooldown.
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if b.state == BreakerHalfOpen {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		return
+	}
+
+	b.consecutiveFailures++
+	if b.consecutiveFailures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// stats returns a snapshot of the breaker's state for endpoint.
+func (b *circuitBreaker) stats(endpoint string) HealthStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return HealthStats{
+		Endpoint:            endpoint,
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+	}
+}
+
+// breakerFor returns the circuit breaker for endpoint, creating it on first
+// use with the client's configured threshold and cooldown.
+func (c *Client) breakerFor(endpoint string) *circuitBreaker {
+	c.breakersMu.Lock()
+	defer c.breakersMu.Unlock()
+
+	b, ok := c.breakers[endpoint]
+	if !ok {
+		b = &circuitBreaker{threshold: c.breakerThreshold, cooldown: c.breakerCooldown}
+		c.breakers[endpoint] = b
+	}
+	return b
+}
+
+// HealthStats returns the circuit breaker state for endpoint (one of the
+// Endpoint* constants). An endpoint with no breaker yet gets a fresh one
+// created on the spot, so it reports a zero-value (closed, no failures)
+// state.
+func (c *Client) HealthStats(endpoint string) HealthStats {
+	return c.breakerFor(endpoint).stats(endpoint)
+}
@@ -0,0 +1,162 @@
+package promptory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_TripsAfterConsecutiveFailures(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.WriteHeader(500)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:                 server.URL,
+		APIKey:                  "pm_test_key",
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.maxRetries = 0 // bypass default (same package has field access)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(context.Background(), "missing"); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if state := client.HealthStats(EndpointGet).State; state != BreakerOpen {
+		t.Fatalf("State = %v, want BreakerOpen", state)
+	}
+
+	before := atomic.LoadInt32(&callCount)
+	_, err = client.Get(context.Background(), "missing")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("err = %v, want ErrCircuitOpen", err)
+	}
+	if atomic.LoadInt32(&callCount) != before {
+		t.Error("expected no request to reach the server while the breaker is open")
+	}
+}
+
+func TestCircuitBreaker_HalfOpenProbeRecovers(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&callCount, 1)
+		if count <= 2 {
+			w.WriteHeader(500)
+			return
+		}
+		json.NewEncoder(w).Encode(samplePrompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:                 server.URL,
+		APIKey:                  "pm_test_key",
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.maxRetries = 0 // bypass default (same package has field access)
+
+	for i := 0; i < 2; i++ {
+		client.Get(context.Background(), samplePrompt.ID)
+	}
+	if state := client.HealthStats(EndpointGet).State; state != BreakerOpen {
+		t.Fatalf("State = %v, want BreakerOpen", state)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if _, err := client.Get(context.Background(), samplePrompt.ID); err != nil {
+		t.Fatalf("expected the half-open probe to succeed, got %v", err)
+	}
+	if state := client.HealthStats(EndpointGet).State; state != BreakerClosed {
+		t.Errorf("State = %v, want BreakerClosed after a successful probe", state)
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailureStreak(t *testing.T) {
+	var fail int32 = 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&fail) == 1 {
+			w.WriteHeader(500)
+			return
+		}
+		json.NewEncoder(w).Encode(samplePrompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:                 server.URL,
+		APIKey:                  "pm_test_key",
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.maxRetries = 0 // bypass default (same package has field access)
+
+	client.Get(context.Background(), samplePrompt.ID)
+	atomic.StoreInt32(&fail, 0)
+	if _, err := client.Get(context.Background(), samplePrompt.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	atomic.StoreInt32(&fail, 1)
+	client.Get(context.Background(), samplePrompt.ID)
+	if state := client.HealthStats(EndpointGet).State; state != BreakerClosed {
+		t.Errorf("State = %v, want BreakerClosed (one failure after a reset streak shouldn't trip threshold 2)", state)
+	}
+}
+
+func TestRetryBudgetExceeded(t *testing.T) {
+	ctx := context.Background()
+	if retryBudgetExceeded(ctx, time.Second) {
+		t.Error("a context with no deadline should never exceed the retry budget")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if !retryBudgetExceeded(ctx, time.Second) {
+		t.Error("a 1s delay against a 10ms deadline should exceed the retry budget")
+	}
+	if retryBudgetExceeded(ctx, time.Nanosecond) {
+		t.Error("a negligible delay should not exceed the retry budget")
+	}
+}
+
+func TestBackoffDelay_FullJitterWithinBounds(t *testing.T) {
+	for attempt := 0; attempt < 5; attempt++ {
+		for i := 0; i < 20; i++ {
+			delay := backoffDelay(attempt, 0)
+			maxDelay := time.Duration(1<<uint(attempt)) * time.Second
+			if maxDelay > 10*time.Second {
+				maxDelay = 10 * time.Second
+			}
+			if delay < 0 || delay > maxDelay {
+				t.Fatalf("backoffDelay(%d, 0) = %v, want within [0, %v]", attempt, delay, maxDelay)
+			}
+		}
+	}
+}
+
+func TestBackoffDelay_RetryAfterIsNotJittered(t *testing.T) {
+	if got := backoffDelay(0, 5); got != 5*time.Second {
+		t.Errorf("backoffDelay(0, 5) = %v, want 5s", got)
+	}
+}
@@ -1,20 +1,35 @@
-package promptdis
+package promptory
 
 import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-// ClientOptions configures a new Promptdis Client.
+const (
+	// defaultBreakerThreshold is the number of consecutive 5xx responses or
+	// transport errors that trips an endpoint's circuit breaker when
+	// ClientOptions.CircuitBreakerThreshold is unset.
+	defaultBreakerThreshold = 5
+
+	// defaultBreakerCooldown is how long a tripped breaker stays open
+	// before probing again when ClientOptions.CircuitBreakerCooldown is
+	// unset.
+	defaultBreakerCooldown = 30 * time.Second
+)
+
+// ClientOptions configures a new Promptory Client.
 type ClientOptions struct {
-	// BaseURL is the Promptdis server URL (required).
+	// BaseURL is the Promptory server URL (required).
 	BaseURL string
 
 	// APIKey is the API key for authentication (required).
@@ -36,25 +51,109 @@ type ClientOptions struct {
 	// HTTPClient is an optional custom HTTP client. If nil, a default client
 	// is created with the configured Timeout.
 	HTTPClient *http.Client
+
+	// Cache overrides the built-in in-process LRU cache with a custom
+	// Cache implementation (e.g. promptory/cache/redis), letting a fleet
+	// of stateless workers share a single warm cache. If nil, a
+	// NewMemoryCache is created from CacheMaxSize and CacheTTL.
+	Cache Cache
+
+	// RateLimit, if set, throttles outgoing requests client-side to
+	// protect a shared API key from tripping server-side 429s. Use
+	// NewTokenBucketLimiter for the default requests/sec + burst
+	// implementation, which also adapts its rate in response to 429s.
+	// If nil, requests are not throttled client-side.
+	RateLimit Limiter
+
+	// MaxConcurrent bounds how many requests Prefetch (and the
+	// GetMany/batch fallback) issue at once (default: 8).
+	MaxConcurrent int
+
+	// PrefetchOnStart is fetched synchronously by NewClient before it
+	// returns, warming the cache so the first user request doesn't pay a
+	// full round trip. Failures are best-effort and do not prevent
+	// NewClient from returning; call Prefetch directly for error
+	// handling.
+	PrefetchOnStart []PromptRef
+
+	// BackgroundRefresh, if set, starts a goroutine that wakes up every
+	// BackgroundRefresh and revalidates cached entries that are about to
+	// expire via conditional If-None-Match requests, so hot prompts never
+	// appear stale to callers. Stopped by Client.Close. If zero, no
+	// background revalidation runs.
+	BackgroundRefresh time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive 5xx responses
+	// or transport errors on a single endpoint that trips its circuit
+	// breaker, short-circuiting further requests with ErrCircuitOpen
+	// (default: 5).
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long a tripped breaker stays open
+	// before allowing a single half-open probe request through (default: 30s).
+	CircuitBreakerCooldown time.Duration
+
+	// VerifyKeys, if set, enables cryptographic verification of prompts:
+	// Get and GetByName switch to the "v2" fetch path, which returns a
+	// signed envelope, and verify each prompt's Signature against the
+	// returned kid before returning it. Verification failure (including a
+	// missing Signature) yields an error satisfying
+	// errors.Is(err, ErrPromptSignature). Use NewStaticKeySet for a fixed
+	// set of keys. If nil, prompts are fetched unverified via "v1".
+	VerifyKeys KeySet
+
+	// WebhookSecret, if set, enables Client.HandleWebhook: the shared
+	// secret it uses to verify each webhook request's
+	// X-Promptory-Signature header (a hex-encoded HMAC-SHA256 of the
+	// request body). HandleWebhook rejects every request if this is
+	// empty.
+	WebhookSecret string
+
+	// Observer, if set, receives request/retry/cache events for tracing
+	// and metrics. Use promptory/contrib/otelpromptory.New to wire these
+	// into OpenTelemetry, or implement Observer directly to keep the
+	// core SDK's zero-dependency property. If nil, events are not
+	// reported.
+	Observer Observer
 }
 
-// Client is the Promptdis SDK client. It is safe for concurrent use.
+// Client is the Promptory SDK client. It is safe for concurrent use.
 type Client struct {
-	baseURL    string
-	apiKey     string
-	cache      *lruCache
-	maxRetries int
-	httpClient *http.Client
+	baseURL       string
+	apiKey        string
+	cache         Cache
+	maxRetries    int
+	httpClient    *http.Client
+	rateLimiter   Limiter
+	maxConcurrent int
+	inflight      *callGroup
+
+	backgroundRefresh time.Duration
+	activeMu          sync.Mutex
+	activeKeys        map[string]string // cacheKey -> request path, for background revalidation
+
+	breakerThreshold int
+	breakerCooldown  time.Duration
+	breakersMu       sync.Mutex
+	breakers         map[string]*circuitBreaker
+
+	verifyKeys    KeySet
+	webhookSecret string
+	observer      Observer
+
+	closeOnce sync.Once
+	bgStop    chan struct{}
+	bgDone    chan struct{}
 }
 
-// NewClient creates a new Promptdis Client with the given options.
+// NewClient creates a new Promptory Client with the given options.
 // BaseURL and APIKey are required; other fields have sensible defaults.
 func NewClient(opts ClientOptions) (*Client, error) {
 	if opts.BaseURL == "" {
-		return nil, &PromptdisError{Message: "BaseURL is required"}
+		return nil, &PromptoryError{Message: "BaseURL is required"}
 	}
 	if opts.APIKey == "" {
-		return nil, &PromptdisError{Message: "APIKey is required"}
+		return nil, &PromptoryError{Message: "APIKey is required"}
 	}
 
 	if opts.CacheMaxSize <= 0 {
@@ -69,26 +168,81 @@ func NewClient(opts ClientOptions) (*Client, error) {
 	if opts.Timeout <= 0 {
 		opts.Timeout = 10 * time.Second
 	}
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = defaultMaxConcurrent
+	}
+	if opts.CircuitBreakerThreshold <= 0 {
+		opts.CircuitBreakerThreshold = defaultBreakerThreshold
+	}
+	if opts.CircuitBreakerCooldown <= 0 {
+		opts.CircuitBreakerCooldown = defaultBreakerCooldown
+	}
 
 	httpClient := opts.HTTPClient
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: opts.Timeout}
 	}
 
-	return &Client{
-		baseURL:    strings.TrimRight(opts.BaseURL, "/"),
-		apiKey:     opts.APIKey,
-		cache:      newLRUCache(opts.CacheMaxSize, opts.CacheTTL),
-		maxRetries: opts.MaxRetries,
-		httpClient: httpClient,
-	}, nil
+	cache := opts.Cache
+	if cache == nil {
+		mc := newLRUCache(opts.CacheMaxSize, opts.CacheTTL)
+		if opts.Observer != nil {
+			mc.onEvict = opts.Observer.ObserveCacheEviction
+		}
+		cache = mc
+	}
+
+	c := &Client{
+		baseURL:           strings.TrimRight(opts.BaseURL, "/"),
+		apiKey:            opts.APIKey,
+		cache:             cache,
+		maxRetries:        opts.MaxRetries,
+		httpClient:        httpClient,
+		rateLimiter:       opts.RateLimit,
+		maxConcurrent:     opts.MaxConcurrent,
+		inflight:          newCallGroup(),
+		backgroundRefresh: opts.BackgroundRefresh,
+		activeKeys:        make(map[string]string),
+		breakerThreshold:  opts.CircuitBreakerThreshold,
+		breakerCooldown:   opts.CircuitBreakerCooldown,
+		breakers:          make(map[string]*circuitBreaker),
+		verifyKeys:        opts.VerifyKeys,
+		webhookSecret:     opts.WebhookSecret,
+		observer:          opts.Observer,
+		bgStop:            make(chan struct{}),
+		bgDone:            make(chan struct{}),
+	}
+
+	if len(opts.PrefetchOnStart) > 0 {
+		_ = c.Prefetch(context.Background(), opts.PrefetchOnStart)
+	}
+
+	if opts.BackgroundRefresh > 0 {
+		go c.backgroundRefreshLoop(opts.BackgroundRefresh)
+	} else {
+		close(c.bgDone)
+	}
+
+	return c, nil
+}
+
+// apiVersion returns "v2" if VerifyKeys is configured (the server returns a
+// signed envelope on this path) or "v1" otherwise.
+func (c *Client) apiVersion() string {
+	if c.verifyKeys != nil {
+		return "v2"
+	}
+	return "v1"
 }
 
 // Get fetches a prompt by UUID.
 func (c *Client) Get(ctx context.Context, promptID string) (*Prompt, error) {
 	cacheKey := "id:" + promptID
-	path := "/api/v1/prompts/" + promptID
-	return c.fetchWithCache(ctx, path, cacheKey)
+	path := fmt.Sprintf("/api/%s/prompts/%s", c.apiVersion(), promptID)
+	attrs := RequestAttributes{PromptID: promptID}
+	return c.observeRequest(EndpointGet, attrs, func() (*Prompt, error) {
+		return c.fetchWithCache(ctx, path, cacheKey, attrs)
+	})
 }
 
 // GetOption configures optional parameters for GetByName.
@@ -120,21 +274,35 @@ func (c *Client) GetByName(ctx context.Context, org, app, name string, opts ...G
 	}
 
 	cacheKey := fmt.Sprintf("name:%s/%s/%s:%s", org, app, name, envSuffix)
-	path := fmt.Sprintf("/api/v1/prompts/by-name/%s/%s/%s%s", org, app, name, pathSuffix)
-	return c.fetchWithCache(ctx, path, cacheKey)
+	path := fmt.Sprintf("/api/%s/prompts/by-name/%s/%s/%s%s", c.apiVersion(), org, app, name, pathSuffix)
+	attrs := RequestAttributes{PromptName: fmt.Sprintf("%s/%s/%s", org, app, name)}
+	return c.observeRequest(EndpointGet, attrs, func() (*Prompt, error) {
+		return c.fetchWithCache(ctx, path, cacheKey, attrs)
+	})
 }
 
 // Render sends variables to the server for full Jinja2 rendering.
 func (c *Client) Render(ctx context.Context, promptID string, variables map[string]interface{}) (*RenderResult, error) {
 	path := "/api/v1/prompts/" + promptID + "/render"
+	attrs := RequestAttributes{PromptID: promptID}
+	start := time.Now()
+
+	result, err := c.render(ctx, path, variables)
 
+	if c.observer != nil {
+		c.observer.ObserveRequest(EndpointRender, time.Since(start), statusCodeFromError(err), attrs)
+	}
+	return result, err
+}
+
+func (c *Client) render(ctx context.Context, path string, variables map[string]interface{}) (*RenderResult, error) {
 	body := map[string]interface{}{"variables": variables}
 	bodyBytes, err := json.Marshal(body)
 	if err != nil {
-		return nil, &PromptdisError{Message: "failed to marshal variables: " + err.Error()}
+		return nil, &PromptoryError{Message: "failed to marshal variables: " + err.Error()}
 	}
 
-	resp, err := c.doRequest(ctx, http.MethodPost, path, bodyBytes)
+	resp, err := c.doRequest(ctx, EndpointRender, http.MethodPost, path, bodyBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -146,11 +314,27 @@ func (c *Client) Render(ctx context.Context, promptID string, variables map[stri
 
 	var result RenderResult
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, &PromptdisError{Message: "failed to decode render response: " + err.Error()}
+		return nil, &PromptoryError{Message: "failed to decode render response: " + err.Error()}
 	}
 	return &result, nil
 }
 
+// statusCodeFromError derives the HTTP status code an Observer should
+// report for a request's outcome: 200 on success, a PromptoryError's
+// StatusCode (including through RateLimitError's Unwrap) if one occurred,
+// or 0 for a transport-level failure that never got an HTTP response
+// (e.g. ErrCircuitOpen, a context cancellation, a dial error).
+func statusCodeFromError(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var pe *PromptoryError
+	if errors.As(err, &pe) {
+		return pe.StatusCode
+	}
+	return 0
+}
+
 // RenderLocal performs basic {{var}} substitution locally.
 // For full Jinja2 rendering, use Render which delegates to the server.
 func (c *Client) RenderLocal(body string, variables map[string]string) string {
@@ -159,60 +343,115 @@ func (c *Client) RenderLocal(body string, variables map[string]string) string {
 
 // CacheStats returns current cache statistics.
 func (c *Client) CacheStats() CacheStats {
-	return c.cache.stats()
+	return c.cache.Stats()
 }
 
 // CacheInvalidate removes a specific cache entry. Returns true if found.
 func (c *Client) CacheInvalidate(key string) bool {
-	return c.cache.invalidate(key)
+	return c.cache.Invalidate(key)
 }
 
 // CacheClear removes all cache entries.
 func (c *Client) CacheClear() {
-	c.cache.clear()
+	c.cache.Clear()
 }
 
-// Close releases resources held by the client (closes idle HTTP connections).
+// Close releases resources held by the client (closes idle HTTP
+// connections and stops the BackgroundRefresh goroutine, if any).
 func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.bgStop)
+	})
+	<-c.bgDone
 	c.httpClient.CloseIdleConnections()
 }
 
 // --- Internal methods ---
 
-func (c *Client) fetchWithCache(ctx context.Context, path, cacheKey string) (*Prompt, error) {
-	cached, fresh := c.cache.get(cacheKey)
+// observeRequest times fn and, if c.observer is set, reports one
+// ObserveRequest event for endpoint: duration, the HTTP status code
+// derived from fn's error (see statusCodeFromError), and attrs enriched
+// with whatever fn's result prompt reveals beyond what the caller already
+// knew (e.g. PromptVersion, or PromptName for a Get(id) call).
+func (c *Client) observeRequest(endpoint string, attrs RequestAttributes, fn func() (*Prompt, error)) (*Prompt, error) {
+	if c.observer == nil {
+		return fn()
+	}
+
+	start := time.Now()
+	prompt, err := fn()
 
+	if prompt != nil {
+		if attrs.PromptID == "" {
+			attrs.PromptID = prompt.ID
+		}
+		if attrs.PromptName == "" {
+			attrs.PromptName = fmt.Sprintf("%s/%s/%s", prompt.Org, prompt.App, prompt.Name)
+		}
+		attrs.PromptVersion = prompt.Version
+	}
+	c.observer.ObserveRequest(endpoint, time.Since(start), statusCodeFromError(err), attrs)
+	return prompt, err
+}
+
+// fetchWithCache serves cacheKey from the cache when fresh, otherwise
+// fetches path and repopulates the cache, conditionally via If-None-Match
+// when a stale entry exists. Concurrent callers for the same cacheKey are
+// coalesced through c.inflight so only one network request is in flight at
+// a time per key.
+func (c *Client) fetchWithCache(ctx context.Context, path, cacheKey string, attrs RequestAttributes) (*Prompt, error) {
+	c.trackActiveKey(cacheKey, path)
+
+	cached, fresh := c.cache.Get(cacheKey)
 	if cached != nil && fresh {
-		return cached.value, nil
+		if c.observer != nil {
+			c.observer.ObserveCache("hit", attrs)
+		}
+		return cached.Value, nil
 	}
 
+	return c.inflight.do(cacheKey, func() (*Prompt, error) {
+		return c.doFetch(ctx, path, cacheKey, cached, attrs)
+	})
+}
+
+func (c *Client) doFetch(ctx context.Context, path, cacheKey string, cached *CacheEntry, attrs RequestAttributes) (*Prompt, error) {
 	headers := map[string]string{}
-	if cached != nil && cached.etag != "" {
-		headers["If-None-Match"] = cached.etag
+	if cached != nil && cached.ETag != "" {
+		headers["If-None-Match"] = cached.ETag
 	}
 
-	resp, err := c.doRequestWithHeaders(ctx, http.MethodGet, path, nil, headers)
+	resp, err := c.doRequestWithHeaders(ctx, EndpointGet, http.MethodGet, path, nil, headers)
 	if err != nil {
 		// On network error, return stale cache if available
 		if cached != nil {
-			return cached.value, nil
+			if c.observer != nil {
+				c.observer.ObserveCache("stale-fallback", attrs)
+			}
+			return cached.Value, nil
 		}
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotModified && cached != nil {
-		c.cache.refreshTTL(cacheKey)
-		return cached.value, nil
+		c.cache.RefreshTTL(cacheKey)
+		if c.observer != nil {
+			c.observer.ObserveCache("revalidated", attrs)
+		}
+		return cached.Value, nil
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		apiErr := c.handleError(resp)
 		// On server error, return stale cache if available
 		if cached != nil {
-			pe, ok := apiErr.(*PromptdisError)
+			pe, ok := apiErr.(*PromptoryError)
 			if ok && pe.StatusCode >= 500 {
-				return cached.value, nil
+				if c.observer != nil {
+					c.observer.ObserveCache("stale-fallback", attrs)
+				}
+				return cached.Value, nil
 			}
 		}
 		return nil, apiErr
@@ -220,23 +459,54 @@ func (c *Client) fetchWithCache(ctx context.Context, path, cacheKey string) (*Pr
 
 	var prompt Prompt
 	if err := json.NewDecoder(resp.Body).Decode(&prompt); err != nil {
-		return nil, &PromptdisError{Message: "failed to decode prompt: " + err.Error()}
+		return nil, &PromptoryError{Message: "failed to decode prompt: " + err.Error()}
+	}
+
+	if c.observer != nil {
+		c.observer.ObserveCache("miss", attrs)
+	}
+
+	if err := c.verifyFetchedPrompt(&prompt); err != nil {
+		return nil, err
 	}
 
+	// The cached Prompt retains Signature, so a later 304 response (above)
+	// is served from the cache without re-verifying against the network.
 	etag := resp.Header.Get("ETag")
-	c.cache.set(cacheKey, &prompt, etag)
+	c.cache.Set(cacheKey, &prompt, etag)
 	return &prompt, nil
 }
 
-func (c *Client) doRequest(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
-	return c.doRequestWithHeaders(ctx, method, path, body, nil)
+func (c *Client) doRequest(ctx context.Context, endpoint, method, path string, body []byte) (*http.Response, error) {
+	return c.doRequestWithHeaders(ctx, endpoint, method, path, body, nil)
 }
 
-func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string, body []byte, extraHeaders map[string]string) (*http.Response, error) {
+// doRequestWithHeaders sends method/path, retrying on transport errors and
+// 429/5xx responses with full-jitter exponential backoff. Retries stop
+// early, without a final pointless sleep, once the remaining context
+// deadline can't fit the next backoff delay. The circuit breaker (see
+// circuitbreaker.go) for endpoint is checked before every attempt, not
+// just the first: if a failure recorded by an earlier attempt trips (or
+// re-trips, for a failed half-open probe) the breaker, the remaining
+// retries are short-circuited with ErrCircuitOpen instead of continuing
+// to hit the network.
+func (c *Client) doRequestWithHeaders(ctx context.Context, endpoint, method, path string, body []byte, extraHeaders map[string]string) (*http.Response, error) {
+	breaker := c.breakerFor(endpoint)
+
 	url := c.baseURL + path
 
 	var lastErr error
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if !breaker.allow() {
+			return nil, &circuitOpenError{endpoint: endpoint}
+		}
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, &PromptoryError{Message: "request cancelled"}
+			}
+		}
+
 		var bodyReader io.Reader
 		if body != nil {
 			bodyReader = bytes.NewReader(body)
@@ -244,7 +514,7 @@ func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string,
 
 		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 		if err != nil {
-			return nil, &PromptdisError{Message: "failed to create request: " + err.Error()}
+			return nil, &PromptoryError{Message: "failed to create request: " + err.Error()}
 		}
 
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
@@ -256,10 +526,17 @@ func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string,
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			lastErr = err
+			breaker.recordFailure()
 			if attempt < c.maxRetries {
 				delay := backoffDelay(attempt, 0)
+				if retryBudgetExceeded(ctx, delay) {
+					break
+				}
+				if c.observer != nil {
+					c.observer.ObserveRetry(endpoint)
+				}
 				if err := sleepCtx(ctx, delay); err != nil {
-					return nil, &PromptdisError{Message: "request cancelled"}
+					return nil, &PromptoryError{Message: "request cancelled"}
 				}
 			}
 			continue
@@ -273,22 +550,58 @@ func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string,
 					retryAfter = parsed
 				}
 			}
+			if resp.StatusCode == 429 {
+				c.observeRateLimited()
+				if c.observer != nil {
+					c.observer.ObserveRateLimitRetryAfter(retryAfter)
+				}
+			} else {
+				breaker.recordFailure()
+			}
 			resp.Body.Close()
 			delay := backoffDelay(attempt, retryAfter)
+			if retryBudgetExceeded(ctx, delay) {
+				lastErr = fmt.Errorf("retry budget exhausted: %s", resp.Status)
+				break
+			}
+			if c.observer != nil {
+				c.observer.ObserveRetry(endpoint)
+			}
 			if err := sleepCtx(ctx, delay); err != nil {
-				return nil, &PromptdisError{Message: "request cancelled"}
+				return nil, &PromptoryError{Message: "request cancelled"}
 			}
 			continue
 		}
 
+		switch {
+		case resp.StatusCode == 429:
+			c.observeRateLimited()
+		case resp.StatusCode >= 500:
+			breaker.recordFailure()
+		default:
+			c.observeRateLimitSuccess()
+			breaker.recordSuccess()
+		}
+
 		return resp, nil
 	}
 
-	return nil, &PromptdisError{
+	return nil, &PromptoryError{
 		Message: fmt.Sprintf("request failed after %d attempts: %v", c.maxRetries+1, lastErr),
 	}
 }
 
+// retryBudgetExceeded reports whether sleeping for delay would run past
+// ctx's deadline, in which case the caller should give up immediately
+// instead of sleeping only to have the context expire mid-backoff.
+func retryBudgetExceeded(ctx context.Context, delay time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return false
+	}
+	return delay > time.Until(deadline)
+}
+
 func (c *Client) handleError(resp *http.Response) error {
 	var body struct {
 		Error struct {
@@ -304,9 +617,9 @@ func (c *Client) handleError(resp *http.Response) error {
 
 	switch resp.StatusCode {
 	case 401:
-		return &PromptdisError{StatusCode: 401, Message: message}
+		return &PromptoryError{StatusCode: 401, Message: message}
 	case 404:
-		return &PromptdisError{StatusCode: 404, Message: message}
+		return &PromptoryError{StatusCode: 404, Message: message}
 	case 429:
 		retryAfter := 0
 		if raHeader := resp.Header.Get("Retry-After"); raHeader != "" {
@@ -315,11 +628,11 @@ func (c *Client) handleError(resp *http.Response) error {
 			}
 		}
 		return &RateLimitError{
-			PromptdisError: PromptdisError{StatusCode: 429, Message: "rate limit exceeded"},
+			PromptoryError: PromptoryError{StatusCode: 429, Message: "rate limit exceeded"},
 			RetryAfter:     retryAfter,
 		}
 	default:
-		return &PromptdisError{
+		return &PromptoryError{
 			StatusCode: resp.StatusCode,
 			Message:    message,
 		}
@@ -327,17 +640,21 @@ func (c *Client) handleError(resp *http.Response) error {
 }
 
 // backoffDelay calculates the delay for a retry attempt.
-// If retryAfterSec > 0, it's used directly. Otherwise, exponential backoff
-// is applied: 1s, 2s, 4s, ... capped at 10s.
+// If retryAfterSec > 0, it's used directly (the server's stated delay isn't
+// jittered). Otherwise, full-jitter exponential backoff is applied: a
+// random delay in [0, cap], where cap doubles each attempt (1s, 2s, 4s, ...)
+// up to 10s. Full jitter, rather than a fixed exponential delay, spreads
+// out retries from many clients so they don't all hammer the server in
+// lockstep after an outage.
 func backoffDelay(attempt, retryAfterSec int) time.Duration {
 	if retryAfterSec > 0 {
 		return time.Duration(retryAfterSec) * time.Second
 	}
-	delay := time.Duration(1<<uint(attempt)) * time.Second
-	if delay > 10*time.Second {
-		delay = 10 * time.Second
+	maxDelay := 10 * time.Second
+	if attempt < 4 {
+		maxDelay = time.Duration(1<<uint(attempt)) * time.Second
 	}
-	return delay
+	return time.Duration(rand.Int63n(int64(maxDelay) + 1))
 }
 
 // sleepCtx sleeps for the given duration, returning early if the context is cancelled.
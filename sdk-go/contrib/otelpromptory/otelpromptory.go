@@ -0,0 +1,130 @@
+// Package otelpromptory wires a promptory.Client's Observer events into an
+// OpenTelemetry TracerProvider and MeterProvider, so request latency,
+// cache behavior, retries, and rate-limiting show up as spans and metrics
+// without the core promptory package taking an OTel dependency (the same
+// one-way-dependency shape as promptory/cache/redis).
+package otelpromptory
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	promptory "github.com/winzeler/promptory/sdk-go"
+)
+
+const instrumentationName = "github.com/winzeler/promptory/sdk-go/contrib/otelpromptory"
+
+// Observer is a promptory.Observer backed by an OTel TracerProvider and
+// MeterProvider. Construct one with New and pass it as
+// ClientOptions.Observer.
+type Observer struct {
+	tracer trace.Tracer
+
+	requestDuration     metric.Float64Histogram
+	cacheResults        metric.Int64Counter
+	cacheEvictions      metric.Int64Counter
+	retryCount          metric.Int64Counter
+	ratelimitRetryAfter metric.Int64Histogram
+}
+
+var _ promptory.Observer = (*Observer)(nil)
+
+// New creates an Observer that reports spans via tp and metrics via mp. It
+// panics if an instrument fails to register, matching the OTel SDK's own
+// convention for surfacing instrument-creation errors at startup rather
+// than on every call.
+func New(tp trace.TracerProvider, mp metric.MeterProvider) *Observer {
+	meter := mp.Meter(instrumentationName)
+
+	requestDuration, err := meter.Float64Histogram("promptory.request.duration",
+		metric.WithDescription("Duration of a Get/GetByName/Render call"),
+		metric.WithUnit("s"))
+	if err != nil {
+		panic(err)
+	}
+	cacheResults, err := meter.Int64Counter("promptory.cache.hits",
+		metric.WithDescription("Cache lookups by result (hit, miss, revalidated, stale-fallback)"))
+	if err != nil {
+		panic(err)
+	}
+	cacheEvictions, err := meter.Int64Counter("promptory.cache.evictions",
+		metric.WithDescription("Entries evicted from the built-in in-process LRU cache"))
+	if err != nil {
+		panic(err)
+	}
+	retryCount, err := meter.Int64Counter("promptory.retry.count",
+		metric.WithDescription("Retried attempts after a transport error or 429/5xx response"))
+	if err != nil {
+		panic(err)
+	}
+	ratelimitRetryAfter, err := meter.Int64Histogram("promptory.ratelimit.retry_after",
+		metric.WithDescription("Retry-After delay, in seconds, reported by a 429 response"),
+		metric.WithUnit("s"))
+	if err != nil {
+		panic(err)
+	}
+
+	return &Observer{
+		tracer:              tp.Tracer(instrumentationName),
+		requestDuration:     requestDuration,
+		cacheResults:        cacheResults,
+		cacheEvictions:      cacheEvictions,
+		retryCount:          retryCount,
+		ratelimitRetryAfter: ratelimitRetryAfter,
+	}
+}
+
+func promptAttrs(attrs promptory.RequestAttributes) []attribute.KeyValue {
+	var kvs []attribute.KeyValue
+	if attrs.PromptID != "" {
+		kvs = append(kvs, attribute.String("prompt.id", attrs.PromptID))
+	}
+	if attrs.PromptName != "" {
+		kvs = append(kvs, attribute.String("prompt.name", attrs.PromptName))
+	}
+	if attrs.PromptVersion != "" {
+		kvs = append(kvs, attribute.String("prompt.version", attrs.PromptVersion))
+	}
+	return kvs
+}
+
+// ObserveRequest implements promptory.Observer. Client reports a request
+// only after it has already completed, so the span is started and ended
+// immediately with an explicit start timestamp rather than spanning the
+// call live.
+func (o *Observer) ObserveRequest(endpoint string, duration time.Duration, statusCode int, attrs promptory.RequestAttributes) {
+	kvs := append(promptAttrs(attrs), attribute.Int("http.status_code", statusCode))
+
+	now := time.Now()
+	_, span := o.tracer.Start(context.Background(), endpoint, trace.WithTimestamp(now.Add(-duration)))
+	span.SetAttributes(kvs...)
+	span.End(trace.WithTimestamp(now))
+
+	o.requestDuration.Record(context.Background(), duration.Seconds(),
+		metric.WithAttributes(append(kvs, attribute.String("endpoint", endpoint))...))
+}
+
+// ObserveRetry implements promptory.Observer.
+func (o *Observer) ObserveRetry(endpoint string) {
+	o.retryCount.Add(context.Background(), 1, metric.WithAttributes(attribute.String("endpoint", endpoint)))
+}
+
+// ObserveCache implements promptory.Observer.
+func (o *Observer) ObserveCache(result string, attrs promptory.RequestAttributes) {
+	kvs := append(promptAttrs(attrs), attribute.String("cache.result", result))
+	o.cacheResults.Add(context.Background(), 1, metric.WithAttributes(kvs...))
+}
+
+// ObserveCacheEviction implements promptory.Observer.
+func (o *Observer) ObserveCacheEviction() {
+	o.cacheEvictions.Add(context.Background(), 1)
+}
+
+// ObserveRateLimitRetryAfter implements promptory.Observer.
+func (o *Observer) ObserveRateLimitRetryAfter(retryAfterSec int) {
+	o.ratelimitRetryAfter.Record(context.Background(), int64(retryAfterSec))
+}
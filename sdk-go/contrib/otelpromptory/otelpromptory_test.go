@@ -0,0 +1,123 @@
+package otelpromptory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	promptory "github.com/winzeler/promptory/sdk-go"
+)
+
+// newTestObserver wires an Observer to an in-memory span exporter and a
+// manual metric reader, so a test can inspect exactly what was emitted.
+func newTestObserver(t *testing.T) (*Observer, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	return New(tp, mp), exporter, reader
+}
+
+func TestObserveRequest_SpanAttributes(t *testing.T) {
+	obs, exporter, _ := newTestObserver(t)
+
+	obs.ObserveRequest(promptory.EndpointGet, 42*time.Millisecond, 200, promptory.RequestAttributes{
+		PromptID:      "p1",
+		PromptName:    "acme/chat/greeting",
+		PromptVersion: "3",
+	})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != promptory.EndpointGet {
+		t.Errorf("span name = %q, want %q", span.Name, promptory.EndpointGet)
+	}
+
+	got := map[string]string{}
+	for _, kv := range span.Attributes {
+		got[string(kv.Key)] = kv.Value.Emit()
+	}
+	if got["prompt.id"] != "p1" {
+		t.Errorf("prompt.id = %q, want %q", got["prompt.id"], "p1")
+	}
+	if got["prompt.name"] != "acme/chat/greeting" {
+		t.Errorf("prompt.name = %q, want %q", got["prompt.name"], "acme/chat/greeting")
+	}
+	if got["prompt.version"] != "3" {
+		t.Errorf("prompt.version = %q, want %q", got["prompt.version"], "3")
+	}
+	if got["http.status_code"] != "200" {
+		t.Errorf("http.status_code = %q, want %q", got["http.status_code"], "200")
+	}
+}
+
+func TestObserveCache_RecordsResultAttribute(t *testing.T) {
+	obs, _, reader := newTestObserver(t)
+
+	obs.ObserveCache("hit", promptory.RequestAttributes{PromptID: "p1"})
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "promptory.cache.hits" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok || len(sum.DataPoints) != 1 {
+				t.Fatalf("unexpected data for %s: %#v", m.Name, m.Data)
+			}
+			found = true
+			for _, attr := range sum.DataPoints[0].Attributes.ToSlice() {
+				if string(attr.Key) == "cache.result" && attr.Value.AsString() != "hit" {
+					t.Errorf("cache.result = %q, want %q", attr.Value.AsString(), "hit")
+				}
+			}
+		}
+	}
+	if !found {
+		t.Fatal("promptory.cache.hits metric not recorded")
+	}
+}
+
+func TestObserveCacheEviction_IncrementsCounter(t *testing.T) {
+	obs, _, reader := newTestObserver(t)
+
+	obs.ObserveCacheEviction()
+	obs.ObserveCacheEviction()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "promptory.cache.evictions" {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 2 {
+				t.Fatalf("unexpected data for %s: %#v", m.Name, m.Data)
+			}
+			return
+		}
+	}
+	t.Fatal("promptory.cache.evictions metric not recorded")
+}
@@ -28,9 +28,45 @@
 //
 //   - Fetch prompts by UUID or fully qualified name (org/app/name)
 //   - LRU cache with TTL and ETag-based conditional fetches
+//   - Pluggable Cache interface (ClientOptions.Cache) with a Redis-backed
+//     implementation in promptory/cache/redis for sharing a warm cache
+//     across a fleet of stateless workers
 //   - Typed errors with errors.Is / errors.As support
 //   - Retry with exponential backoff on 429/5xx
-//   - Basic {{var}} local rendering
-//   - Zero external dependencies (stdlib only)
+//   - Optional client-side rate limiting (ClientOptions.RateLimit) with an
+//     adaptive token-bucket implementation that backs off on 429s
+//   - Startup prefetch (Prefetch, ClientOptions.PrefetchOnStart) and
+//     background revalidation (ClientOptions.BackgroundRefresh) to keep
+//     hot prompts warm, with singleflight coalescing of concurrent fetches
+//   - Batch fetching (GetMany, PrefetchByName): a single POST warms many
+//     prompts at once, falling back to bounded-concurrency (MaxConcurrent)
+//     parallel fetches if the server lacks the batch route; concurrent
+//     calls are coalesced per ID, so overlapping-but-different ID sets
+//     still share a cold ID's fetch instead of each issuing their own call
+//   - Full-jitter retry backoff with a deadline-aware retry budget, and a
+//     per-endpoint circuit breaker (ClientOptions.CircuitBreakerThreshold,
+//     CircuitBreakerCooldown; Client.HealthStats) that short-circuits a
+//     repeatedly-failing endpoint with ErrCircuitOpen
+//   - Optional JWS prompt signature verification (ClientOptions.VerifyKeys)
+//     over a "v2" fetch path, supporting RS256/ES256/EdDSA and key rotation
+//     via KeySet; Prompt.Verify revalidates a cached or disk-loaded prompt
+//   - Streaming Watch/WatchByName API for live prompt updates
+//   - Push-based cache invalidation: Client.Subscribe opens an SSE stream
+//     (auto-reconnecting with Last-Event-ID resume) that invalidates and
+//     optionally pre-warms (SubscribeOptions.Prefetch) changed cache
+//     entries; Client.HandleWebhook applies the same CacheEvents from an
+//     HMAC-signed webhook (ClientOptions.WebhookSecret) for callers that
+//     can't hold a long-lived connection open
+//   - Basic {{var}} local rendering (RenderLocal), or a full offline
+//     Jinja2-subset renderer (Client.RenderOffline, promptory/render) with
+//     filters, if/for, set, and include — no network round trip
+//   - Pluggable observability (ClientOptions.Observer): every Get,
+//     GetByName, and Render reports duration/status/attrs, alongside cache
+//     hit/miss/revalidation/stale-fallback, retries, cache evictions, and
+//     rate-limit Retry-After delays; promptory/contrib/otelpromptory wires
+//     these into an OpenTelemetry TracerProvider and MeterProvider
+//   - Zero external dependencies in the core package (stdlib only);
+//     optional integrations such as promptory/cache/redis and
+//     promptory/contrib/otelpromptory live in their own subpackages
 //   - Goroutine-safe (sync.RWMutex protected cache)
 package promptory
@@ -1,8 +1,8 @@
-package promptdis
+package promptory
 
 import "regexp"
 
-// Prompt represents a prompt returned from the Promptdis API.
+// Prompt represents a prompt returned from the Promptory API.
 type Prompt struct {
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name"`
@@ -24,6 +24,11 @@ type Prompt struct {
 	Includes    []string               `json:"includes"`
 	GitSHA      *string                `json:"git_sha"`
 	UpdatedAt   *string                `json:"updated_at"`
+
+	// Signature is the JWS signature metadata the server attaches when
+	// cryptographic verification is enabled (the "v2" fetch path). It is
+	// nil for prompts fetched without a ClientOptions.VerifyKeys set.
+	Signature *PromptSignature `json:"signature,omitempty"`
 }
 
 // ModelDefault returns the default model name from the model config.
@@ -94,7 +99,9 @@ var varPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
 
 // RenderLocal performs basic {{variable}} substitution on a prompt body.
 // Variables not found in the map are replaced with an empty string.
-// For full Jinja2 rendering (conditionals, loops, includes), use Client.Render.
+// For full Jinja2 rendering (conditionals, loops, filters, includes), use
+// Client.Render (server-side) or Client.RenderOffline (local, no network
+// round trip).
 func RenderLocal(body string, variables map[string]string) string {
 	return varPattern.ReplaceAllStringFunc(body, func(match string) string {
 		groups := varPattern.FindStringSubmatch(match)
@@ -1,4 +1,4 @@
-package promptdis
+package promptory
 
 import "testing"
 
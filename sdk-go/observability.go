@@ -0,0 +1,69 @@
+package promptory
+
+import (
+	"strings"
+	"time"
+)
+
+// RequestAttributes describes the prompt a request or cache event
+// concerns, for use as Observer span/metric attributes. Any field may be
+// empty if not yet known (e.g. PromptName is unset for a Get(id) call).
+type RequestAttributes struct {
+	PromptID      string
+	PromptName    string
+	PromptVersion string
+}
+
+// Observer receives Client's observability events. It is the
+// zero-dependency seam between the core SDK and a tracing/metrics
+// backend: implement it directly (e.g. to log or feed Prometheus), or use
+// promptory/contrib/otelpromptory.New to forward these into an
+// otel.TracerProvider and metric.MeterProvider. If ClientOptions.Observer
+// is nil, events are simply not reported.
+type Observer interface {
+	// ObserveRequest reports the outcome of one top-level call (Get,
+	// GetByName, or Render): duration is wall-clock time spent in the
+	// call, statusCode is the final HTTP status code (0 if no response
+	// was ever received, e.g. a transport error or a circuit-open
+	// short-circuit), and attrs identifies the prompt involved, filled in
+	// as much as is known by the time the call returns.
+	ObserveRequest(endpoint string, duration time.Duration, statusCode int, attrs RequestAttributes)
+
+	// ObserveRetry reports a single retried attempt (a backoff-and-retry
+	// triggered by a transport error or a 429/5xx response) for endpoint.
+	ObserveRetry(endpoint string)
+
+	// ObserveCache reports a single cache lookup outcome for a
+	// Get/GetByName/Prefetch call: "hit" (served from cache, no network
+	// call), "miss" (not cached or stale, fetched a fresh 200),
+	// "revalidated" (stale entry confirmed unchanged via a 304), or
+	// "stale-fallback" (a network or server error was masked by serving a
+	// stale entry).
+	ObserveCache(result string, attrs RequestAttributes)
+
+	// ObserveCacheEviction reports a single entry evicted from the
+	// built-in in-process LRU cache to stay within CacheMaxSize. Not
+	// reported for a custom ClientOptions.Cache, which owns its own
+	// eviction policy.
+	ObserveCacheEviction()
+
+	// ObserveRateLimitRetryAfter reports a 429 response's Retry-After
+	// delay in seconds (0 if the response had no Retry-After header).
+	ObserveRateLimitRetryAfter(retryAfterSec int)
+}
+
+// attrsFromCacheKey recovers the best-effort RequestAttributes encoded in
+// a cacheKey ("id:<id>" or "name:<org>/<app>/<name>:<env>"), for call
+// sites like background revalidation that only have the key on hand.
+func attrsFromCacheKey(cacheKey string) RequestAttributes {
+	if id, ok := strings.CutPrefix(cacheKey, "id:"); ok {
+		return RequestAttributes{PromptID: id}
+	}
+	if name, ok := strings.CutPrefix(cacheKey, "name:"); ok {
+		if idx := strings.LastIndexByte(name, ':'); idx >= 0 {
+			name = name[:idx]
+		}
+		return RequestAttributes{PromptName: name}
+	}
+	return RequestAttributes{}
+}
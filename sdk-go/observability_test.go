@@ -0,0 +1,142 @@
+package promptory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeObserver records every call made to it, guarded by a mutex since
+// Client may invoke it from multiple goroutines (e.g. background refresh).
+type fakeObserver struct {
+	mu sync.Mutex
+
+	requests       []fakeRequest
+	cacheResults   []string
+	cacheAttrs     []RequestAttributes
+	retries        []string
+	evictions      int
+	retryAfterSecs []int
+}
+
+type fakeRequest struct {
+	endpoint   string
+	statusCode int
+	attrs      RequestAttributes
+}
+
+func (f *fakeObserver) ObserveRequest(endpoint string, duration time.Duration, statusCode int, attrs RequestAttributes) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, fakeRequest{endpoint: endpoint, statusCode: statusCode, attrs: attrs})
+}
+
+func (f *fakeObserver) ObserveRetry(endpoint string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.retries = append(f.retries, endpoint)
+}
+
+func (f *fakeObserver) ObserveCache(result string, attrs RequestAttributes) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cacheResults = append(f.cacheResults, result)
+	f.cacheAttrs = append(f.cacheAttrs, attrs)
+}
+
+func (f *fakeObserver) ObserveCacheEviction() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.evictions++
+}
+
+func (f *fakeObserver) ObserveRateLimitRetryAfter(retryAfterSec int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.retryAfterSecs = append(f.retryAfterSecs, retryAfterSec)
+}
+
+var _ Observer = (*fakeObserver)(nil)
+
+func TestGet_ObservesRequestAndCacheMiss(t *testing.T) {
+	obs := &fakeObserver{}
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&Prompt{ID: "p1", Org: "acme", App: "chat", Name: "greeting", Version: "1"})
+	})
+	defer server.Close()
+	client.observer = obs
+
+	if _, err := client.Get(context.Background(), "p1"); err != nil {
+		t.Fatal(err)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	if len(obs.requests) != 1 {
+		t.Fatalf("got %d ObserveRequest calls, want 1", len(obs.requests))
+	}
+	got := obs.requests[0]
+	if got.endpoint != EndpointGet || got.statusCode != http.StatusOK {
+		t.Errorf("got %+v", got)
+	}
+	if got.attrs.PromptID != "p1" || got.attrs.PromptName != "acme/chat/greeting" || got.attrs.PromptVersion != "1" {
+		t.Errorf("attrs = %+v", got.attrs)
+	}
+	if len(obs.cacheResults) != 1 || obs.cacheResults[0] != "miss" {
+		t.Errorf("cacheResults = %v, want [miss]", obs.cacheResults)
+	}
+}
+
+func TestGet_ObservesCacheHit(t *testing.T) {
+	obs := &fakeObserver{}
+	calls := 0
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		json.NewEncoder(w).Encode(&Prompt{ID: "p1"})
+	})
+	defer server.Close()
+	client.observer = obs
+
+	ctx := context.Background()
+	if _, err := client.Get(ctx, "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Get(ctx, "p1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("server called %d times, want 1 (second Get should hit cache)", calls)
+	}
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	if len(obs.cacheResults) != 2 || obs.cacheResults[0] != "miss" || obs.cacheResults[1] != "hit" {
+		t.Errorf("cacheResults = %v, want [miss hit]", obs.cacheResults)
+	}
+}
+
+func TestClient_ObservesCacheEviction(t *testing.T) {
+	obs := &fakeObserver{}
+	client, err := NewClient(ClientOptions{
+		BaseURL:      "https://example.invalid",
+		APIKey:       "pm_test_key",
+		CacheMaxSize: 1,
+		Observer:     obs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.cache.Set("id:p1", &Prompt{ID: "p1"}, "v1")
+	client.cache.Set("id:p2", &Prompt{ID: "p2"}, "v1")
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	if obs.evictions != 1 {
+		t.Errorf("evictions = %d, want 1", obs.evictions)
+	}
+}
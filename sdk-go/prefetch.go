@@ -0,0 +1,200 @@
+package promptory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultMaxConcurrent bounds Prefetch and the GetMany/batch fallback when
+// ClientOptions.MaxConcurrent is unset.
+const defaultMaxConcurrent = 8
+
+// defaultRevalidationTimeout bounds each background-revalidation request
+// when c.httpClient.Timeout can't be trusted to (see revalidateExpiringSoon).
+const defaultRevalidationTimeout = 10 * time.Second
+
+// PromptRef identifies a single prompt to prefetch, either by UUID (ID) or
+// by fully qualified name (Org/App/Name, optionally scoped to
+// Environment). Exactly one of ID or Name should be set.
+type PromptRef struct {
+	ID string
+
+	Org         string
+	App         string
+	Name        string
+	Environment string
+}
+
+// cacheKeyAndPath mirrors the cache key and request path construction used
+// by Get and GetByName, so a prefetched entry lands under the same cache
+// key a later Get/GetByName call will look up.
+func (r PromptRef) cacheKeyAndPath(apiVersion string) (cacheKey, path string) {
+	if r.ID != "" {
+		return "id:" + r.ID, fmt.Sprintf("/api/%s/prompts/%s", apiVersion, r.ID)
+	}
+
+	envSuffix := "any"
+	pathSuffix := ""
+	if r.Environment != "" {
+		envSuffix = r.Environment
+		pathSuffix = "?environment=" + r.Environment
+	}
+
+	cacheKey = fmt.Sprintf("name:%s/%s/%s:%s", r.Org, r.App, r.Name, envSuffix)
+	path = fmt.Sprintf("/api/%s/prompts/by-name/%s/%s/%s%s", apiVersion, r.Org, r.App, r.Name, pathSuffix)
+	return cacheKey, path
+}
+
+// Prefetch warms the cache for each ref in refs, so a later Get/GetByName
+// for the same prompt is served from the cache instead of paying a full
+// round trip. Requests are issued with bounded concurrency
+// (ClientOptions.MaxConcurrent) and deduped against any in-flight fetch for
+// the same cache key via the same singleflight coalescing Get uses.
+//
+// Prefetch returns the first error encountered, if any; refs that
+// succeeded remain cached even if a later ref fails.
+func (c *Client) Prefetch(ctx context.Context, refs []PromptRef) error {
+	sem := make(chan struct{}, c.maxConcurrent)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for _, ref := range refs {
+		ref := ref
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			cacheKey, path := ref.cacheKeyAndPath(c.apiVersion())
+			if _, err := c.fetchWithCache(ctx, path, cacheKey, attrsFromCacheKey(cacheKey)); err != nil {
+				once.Do(func() { firstErr = err })
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// trackActiveKey records the request path used to populate cacheKey, so
+// the BackgroundRefresh goroutine can later revalidate it without needing
+// the Cache implementation to support enumeration.
+func (c *Client) trackActiveKey(cacheKey, path string) {
+	if c.backgroundRefresh <= 0 {
+		return
+	}
+	c.activeMu.Lock()
+	c.activeKeys[cacheKey] = path
+	c.activeMu.Unlock()
+}
+
+// backgroundRefreshLoop wakes up every interval and revalidates cached
+// entries that are about to expire before the next tick, so hot prompts
+// never appear stale to callers. It exits, closing c.bgDone, when bgStop
+// is closed by Client.Close.
+func (c *Client) backgroundRefreshLoop(interval time.Duration) {
+	defer close(c.bgDone)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.bgStop:
+			return
+		case <-ticker.C:
+			c.revalidateExpiringSoon(interval)
+		}
+	}
+}
+
+// revalidateExpiringSoon issues a conditional fetch for every tracked key
+// whose cache entry expires within horizon of now, refreshing its TTL on a
+// 304 or replacing it on a 200. The request is sent even while the entry
+// is still fresh, and is coalesced with any concurrent fetch of the same
+// key through c.inflight.
+//
+// Each request is bounded by c.httpClient.Timeout, falling back to
+// defaultRevalidationTimeout when that's <= 0: ClientOptions.HTTPClient
+// lets a caller supply their own *http.Client, and one built with a zero
+// Timeout (relying on context deadlines per call, a common pattern)
+// would otherwise make context.WithTimeout(ctx, 0) expire before the
+// request is ever sent, silently failing every background revalidation.
+func (c *Client) revalidateExpiringSoon(horizon time.Duration) {
+	c.activeMu.Lock()
+	keys := make(map[string]string, len(c.activeKeys))
+	for k, v := range c.activeKeys {
+		keys[k] = v
+	}
+	c.activeMu.Unlock()
+
+	now := time.Now()
+	for key, path := range keys {
+		entry, _ := c.cache.Get(key)
+		if entry == nil {
+			c.activeMu.Lock()
+			delete(c.activeKeys, key)
+			c.activeMu.Unlock()
+			continue
+		}
+		if entry.ExpiresAt.Sub(now) > horizon {
+			continue
+		}
+
+		timeout := c.httpClient.Timeout
+		if timeout <= 0 {
+			timeout = defaultRevalidationTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		attrs := attrsFromCacheKey(key)
+		_, _ = c.inflight.do(key, func() (*Prompt, error) {
+			return c.doFetch(ctx, path, key, entry, attrs)
+		})
+		cancel()
+	}
+}
+
+// callGroup coalesces concurrent calls for the same key into a single
+// execution of fn, so that N goroutines racing to populate a cold cache
+// key produce exactly one network request (a stdlib-only equivalent of
+// golang.org/x/sync/singleflight.Group, scoped to this package's needs).
+type callGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+type inflightCall struct {
+	wg    sync.WaitGroup
+	value *Prompt
+	err   error
+}
+
+func newCallGroup() *callGroup {
+	return &callGroup{calls: make(map[string]*inflightCall)}
+}
+
+func (g *callGroup) do(key string, fn func() (*Prompt, error)) (*Prompt, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.value, call.err
+	}
+
+	call := &inflightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.value, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.value, call.err
+}
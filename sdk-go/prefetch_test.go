@@ -0,0 +1,155 @@
+package promptory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPrefetch_PopulatesCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(samplePrompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Prefetch(context.Background(), []PromptRef{
+		{ID: samplePrompt.ID},
+		{Org: "myorg", App: "myapp", Name: "greeting"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stats := client.CacheStats(); stats.Size != 2 {
+		t.Errorf("CacheStats().Size = %d, want 2", stats.Size)
+	}
+}
+
+func TestPrefetch_CoalescesConcurrentFetches(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		time.Sleep(20 * time.Millisecond)
+		json.NewEncoder(w).Encode(samplePrompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 10
+	done := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := client.Get(context.Background(), samplePrompt.ID)
+			done <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&callCount); got != 1 {
+		t.Errorf("server saw %d requests, want 1 (coalesced)", got)
+	}
+}
+
+func TestPrefetch_ReturnsFirstError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{"message": "not found"},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Prefetch(context.Background(), []PromptRef{{ID: "missing"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestBackgroundRefresh_RevalidatesWithZeroTimeoutHTTPClient(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(samplePrompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL: server.URL,
+		APIKey:  "pm_test_key",
+		// A bare &http.Client{} has Timeout == 0, relying on a per-call
+		// context deadline instead - a supported ClientOptions.HTTPClient
+		// pattern that must not make background revalidation use a
+		// context.WithTimeout(ctx, 0), which expires before it's ever sent.
+		HTTPClient:        &http.Client{},
+		CacheTTL:          40 * time.Millisecond,
+		BackgroundRefresh: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.Get(context.Background(), samplePrompt.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&callCount); got < 2 {
+		t.Errorf("server saw %d requests, want at least 2 (initial + background revalidation)", got)
+	}
+}
+
+func TestBackgroundRefresh_RevalidatesBeforeExpiry(t *testing.T) {
+	var callCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&callCount, 1)
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(samplePrompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:           server.URL,
+		APIKey:            "pm_test_key",
+		CacheTTL:          40 * time.Millisecond,
+		BackgroundRefresh: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.Get(context.Background(), samplePrompt.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&callCount); got < 2 {
+		t.Errorf("server saw %d requests, want at least 2 (initial + background revalidation)", got)
+	}
+}
@@ -0,0 +1,189 @@
+package promptory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter throttles outgoing requests. Wait blocks until a request may
+// proceed or ctx is done, whichever comes first.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// RateLimitObserver is implemented by Limiters that want to react to
+// server-reported rate limiting. When configured, Client calls
+// ObserveRateLimited after every 429 response and ObserveSuccess after
+// every non-429, non-5xx response, so the limiter can adapt its rate
+// without Client knowing its internals. The default token-bucket limiter
+// implements this to run an AIMD backoff/recovery cycle.
+type RateLimitObserver interface {
+	ObserveRateLimited()
+	ObserveSuccess()
+}
+
+// LimiterStats reports the current state of a rate limiter, analogous to
+// CacheStats for the cache.
+type LimiterStats struct {
+	// EffectiveRate is the limiter's current allowed rate, in requests
+	// per second. It may be below CeilingRate while recovering from an
+	// AIMD backoff.
+	EffectiveRate float64
+
+	// CeilingRate is the configured maximum rate, in requests per second.
+	CeilingRate float64
+
+	// Burst is the configured token bucket burst size.
+	Burst int
+}
+
+// tokenBucketLimiter is the default Limiter: a token bucket that also
+// implements AIMD adaptive behavior. On every 429 it halves the effective
+// rate; on every successful response it accumulates toward a linear
+// recovery back to the configured ceiling, advancing one step every
+// recoverEvery successes.
+type tokenBucketLimiter struct {
+	mu      sync.Mutex
+	ceiling float64 // configured requests/sec ceiling
+	rate    float64 // current effective requests/sec
+	burst   int
+	tokens  float64
+	last    time.Time
+
+	successStreak int
+	recoverEvery  int     // successes needed per recovery step
+	recoverStep   float64 // requests/sec added per recovery step
+}
+
+const (
+	minEffectiveRate    = 0.1 // requests/sec floor so AIMD backoff never reaches zero
+	defaultRecoverEvery = 10  // successes between recovery steps
+)
+
+var _ Limiter = (*tokenBucketLimiter)(nil)
+var _ RateLimitObserver = (*tokenBucketLimiter)(nil)
+
+// NewTokenBucketLimiter creates the default Limiter: a token bucket
+// allowing requestsPerSec sustained requests per second with bursts up to
+// burst. Assign the result to ClientOptions.RateLimit to enable it.
+func NewTokenBucketLimiter(requestsPerSec float64, burst int) Limiter {
+	if requestsPerSec <= 0 {
+		requestsPerSec = 1
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucketLimiter{
+		ceiling:      requestsPerSec,
+		rate:         requestsPerSec,
+		burst:        burst,
+		tokens:       float64(burst),
+		last:         time.Now(),
+		recoverEvery: defaultRecoverEvery,
+		recoverStep:  requestsPerSec / float64(defaultRecoverEvery),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done. It respects
+// ctx.Done() even while sleeping for a token to refill.
+func (l *tokenBucketLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		l.refill()
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepCtx(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// refill tops up the bucket based on elapsed time. Caller must hold l.mu.
+func (l *tokenBucketLimiter) refill() {
+	now := time.Now()
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	l.last = now
+	l.tokens += elapsed * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+}
+
+// ObserveRateLimited halves the effective rate (AIMD multiplicative
+// decrease) in response to a 429, floored at minEffectiveRate, and resets
+// the recovery streak.
+func (l *tokenBucketLimiter) ObserveRateLimited() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate /= 2
+	if l.rate < minEffectiveRate {
+		l.rate = minEffectiveRate
+	}
+	l.successStreak = 0
+}
+
+// ObserveSuccess advances the AIMD linear recovery: every recoverEvery
+// consecutive successes, the effective rate climbs by recoverStep toward
+// ceiling.
+func (l *tokenBucketLimiter) ObserveSuccess() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate >= l.ceiling {
+		l.successStreak = 0
+		return
+	}
+	l.successStreak++
+	if l.successStreak >= l.recoverEvery {
+		l.successStreak = 0
+		l.rate += l.recoverStep
+		if l.rate > l.ceiling {
+			l.rate = l.ceiling
+		}
+	}
+}
+
+// Stats returns the limiter's current state.
+func (l *tokenBucketLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return LimiterStats{
+		EffectiveRate: l.rate,
+		CeilingRate:   l.ceiling,
+		Burst:         l.burst,
+	}
+}
+
+// LimiterStats returns the current state of ClientOptions.RateLimit.
+// It returns a zero LimiterStats if no limiter is configured, or if the
+// configured Limiter doesn't expose stats.
+func (c *Client) LimiterStats() LimiterStats {
+	if sp, ok := c.rateLimiter.(interface{ Stats() LimiterStats }); ok {
+		return sp.Stats()
+	}
+	return LimiterStats{}
+}
+
+func (c *Client) observeRateLimited() {
+	if rl, ok := c.rateLimiter.(RateLimitObserver); ok {
+		rl.ObserveRateLimited()
+	}
+}
+
+func (c *Client) observeRateLimitSuccess() {
+	if rl, ok := c.rateLimiter.(RateLimitObserver); ok {
+		rl.ObserveSuccess()
+	}
+}
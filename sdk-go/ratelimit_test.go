@@ -0,0 +1,101 @@
+package promptory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketLimiter_AllowsBurst(t *testing.T) {
+	l := NewTokenBucketLimiter(10, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("burst of 3 took %v, want near-instant", elapsed)
+	}
+}
+
+func TestTokenBucketLimiter_BlocksBeyondBurst(t *testing.T) {
+	l := NewTokenBucketLimiter(20, 1)
+	ctx := context.Background()
+
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected Wait to block for a refill, elapsed = %v", elapsed)
+	}
+}
+
+func TestTokenBucketLimiter_RespectsContextCancel(t *testing.T) {
+	l := NewTokenBucketLimiter(1, 1)
+	ctx := context.Background()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := l.Wait(cancelCtx); err == nil {
+		t.Error("expected Wait to return an error for a canceled context")
+	}
+}
+
+func TestTokenBucketLimiter_AIMDBackoffAndRecovery(t *testing.T) {
+	l := NewTokenBucketLimiter(100, 10).(*tokenBucketLimiter)
+
+	l.ObserveRateLimited()
+	stats := l.Stats()
+	if stats.EffectiveRate != 50 {
+		t.Errorf("EffectiveRate after one 429 = %v, want 50", stats.EffectiveRate)
+	}
+
+	l.ObserveRateLimited()
+	stats = l.Stats()
+	if stats.EffectiveRate != 25 {
+		t.Errorf("EffectiveRate after two 429s = %v, want 25", stats.EffectiveRate)
+	}
+
+	for i := 0; i < l.recoverEvery; i++ {
+		l.ObserveSuccess()
+	}
+	stats = l.Stats()
+	if stats.EffectiveRate <= 25 {
+		t.Errorf("EffectiveRate after recovery streak = %v, want > 25", stats.EffectiveRate)
+	}
+	if stats.CeilingRate != 100 {
+		t.Errorf("CeilingRate = %v, want 100", stats.CeilingRate)
+	}
+}
+
+func TestTokenBucketLimiter_RateNeverExceedsCeiling(t *testing.T) {
+	l := NewTokenBucketLimiter(10, 5).(*tokenBucketLimiter)
+	for i := 0; i < 10_000; i++ {
+		l.ObserveSuccess()
+	}
+	if stats := l.Stats(); stats.EffectiveRate > stats.CeilingRate {
+		t.Errorf("EffectiveRate %v exceeds CeilingRate %v", stats.EffectiveRate, stats.CeilingRate)
+	}
+}
+
+func TestClient_LimiterStats_NoLimiterConfigured(t *testing.T) {
+	client, err := NewClient(ClientOptions{BaseURL: "https://example.com", APIKey: "pm_test_123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats := client.LimiterStats(); stats != (LimiterStats{}) {
+		t.Errorf("LimiterStats() = %+v, want zero value", stats)
+	}
+}
@@ -0,0 +1,111 @@
+package render
+
+// Node is a piece of parsed template: literal text, an output expression,
+// or a control-flow block.
+type Node interface {
+	isNode()
+}
+
+// TextNode is raw template text emitted verbatim.
+type TextNode struct {
+	Text string
+}
+
+// OutputNode is a {{ expr }} tag. Src is the trimmed original expression
+// source, used to reconstruct the tag verbatim under UndefinedPolicy
+// Preserve.
+type OutputNode struct {
+	Expr Expr
+	Src  string
+}
+
+type ifBranch struct {
+	Cond Expr
+	Body []Node
+}
+
+// IfNode is a {% if %}...{% elif %}...{% else %}...{% endif %} block.
+type IfNode struct {
+	Branches []ifBranch
+	Else     []Node
+}
+
+// ForNode is a {% for Var in Iter %}...{% endfor %} block.
+type ForNode struct {
+	Var  string
+	Iter Expr
+	Body []Node
+}
+
+// SetNode is a {% set Name = Expr %} tag.
+type SetNode struct {
+	Name string
+	Expr Expr
+}
+
+// IncludeNode is a {% include Name %} tag, where Name usually evaluates to
+// a string literal but may be any expression.
+type IncludeNode struct {
+	Name Expr
+}
+
+func (*TextNode) isNode()    {}
+func (*OutputNode) isNode()  {}
+func (*IfNode) isNode()      {}
+func (*ForNode) isNode()     {}
+func (*SetNode) isNode()     {}
+func (*IncludeNode) isNode() {}
+
+// Expr is a parsed expression, evaluated by eval().
+type Expr interface {
+	isExpr()
+}
+
+// LitExpr is a literal value: string, float64, bool, or nil.
+type LitExpr struct {
+	Value interface{}
+}
+
+// IdentExpr is a bare variable reference.
+type IdentExpr struct {
+	Name string
+}
+
+// AttrExpr is dotted attribute access: Base.Name.
+type AttrExpr struct {
+	Base Expr
+	Name string
+}
+
+// IndexExpr is bracketed index/key access: Base[Index].
+type IndexExpr struct {
+	Base  Expr
+	Index Expr
+}
+
+// FilterExpr applies a named filter to Base: Base|Name(Args...).
+type FilterExpr struct {
+	Base Expr
+	Name string
+	Args []Expr
+}
+
+// BinExpr is a binary comparison or logical operator.
+type BinExpr struct {
+	Op    tokenKind
+	Left  Expr
+	Right Expr
+}
+
+// NotExpr negates X.
+type NotExpr struct {
+	X Expr
+}
+
+func (*LitExpr) isExpr()    {}
+func (*IdentExpr) isExpr()  {}
+func (*AttrExpr) isExpr()   {}
+func (*IndexExpr) isExpr()  {}
+func (*FilterExpr) isExpr() {}
+func (*BinExpr) isExpr()    {}
+func (*NotExpr) isExpr()    {}
@@ -0,0 +1,439 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// scope is a chain of variable bindings: {% set %} writes into the
+// innermost scope, {% for %} pushes a fresh child scope per loop so its
+// loop variable and "loop" don't leak, and lookups walk outward to the
+// template's top-level Vars.
+type scope struct {
+	vars   map[string]interface{}
+	parent *scope
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{vars: make(map[string]interface{}), parent: parent}
+}
+
+func (s *scope) get(name string) (interface{}, bool) {
+	for cur := s; cur != nil; cur = cur.parent {
+		if v, ok := cur.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scope) set(name string, value interface{}) {
+	s.vars[name] = value
+}
+
+// undefinedValue marks a variable/attribute/index reference that wasn't
+// found, so it can propagate through further attr/index access and be
+// resolved at the last moment (stringify, truthy, or the default filter)
+// according to UndefinedPolicy instead of failing eagerly.
+type undefinedValue struct {
+	path string // e.g. "user.name", for error messages and Preserve
+}
+
+// evalContext carries per-render state through exec/eval.
+type evalContext struct {
+	ctx       context.Context
+	sc        *scope
+	undefined UndefinedPolicy
+	include   IncludeResolver
+	depth     int // include nesting guard
+}
+
+const maxIncludeDepth = 10
+
+func execNodes(nodes []Node, ec *evalContext, out *strings.Builder) error {
+	for _, n := range nodes {
+		if err := exec(n, ec, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exec(n Node, ec *evalContext, out *strings.Builder) error {
+	switch node := n.(type) {
+	case *TextNode:
+		out.WriteString(node.Text)
+		return nil
+
+	case *OutputNode:
+		v, err := eval(node.Expr, ec)
+		if err != nil {
+			return err
+		}
+		s, err := stringify(v, ec, node.Src)
+		if err != nil {
+			return err
+		}
+		out.WriteString(s)
+		return nil
+
+	case *IfNode:
+		for _, branch := range node.Branches {
+			v, err := eval(branch.Cond, ec)
+			if err != nil {
+				return err
+			}
+			if truthy(v) {
+				return execNodes(branch.Body, ec, out)
+			}
+		}
+		return execNodes(node.Else, ec, out)
+
+	case *ForNode:
+		return execFor(node, ec, out)
+
+	case *SetNode:
+		v, err := eval(node.Expr, ec)
+		if err != nil {
+			return err
+		}
+		ec.sc.set(node.Name, v)
+		return nil
+
+	case *IncludeNode:
+		return execInclude(node, ec, out)
+
+	default:
+		return fmt.Errorf("render: unhandled node type %T", n)
+	}
+}
+
+func execFor(node *ForNode, ec *evalContext, out *strings.Builder) error {
+	iterVal, err := eval(node.Iter, ec)
+	if err != nil {
+		return err
+	}
+
+	items, err := toIterable(iterVal)
+	if err != nil {
+		return err
+	}
+
+	parent := ec.sc
+	for i, item := range items {
+		ec.sc = newScope(parent)
+		ec.sc.set(node.Var, item)
+		ec.sc.set("loop", map[string]interface{}{
+			"index":  float64(i + 1),
+			"index0": float64(i),
+			"first":  i == 0,
+			"last":   i == len(items)-1,
+			"length": float64(len(items)),
+		})
+		if err := execNodes(node.Body, ec, out); err != nil {
+			ec.sc = parent
+			return err
+		}
+	}
+	ec.sc = parent
+	return nil
+}
+
+// toIterable normalizes a value into a slice for {% for %}. Maps iterate
+// their keys in sorted order for determinism; an undefined iterable
+// produces zero iterations rather than an error, since looping over
+// "nothing to loop over" is a reasonable no-op regardless of policy.
+func toIterable(v interface{}) ([]interface{}, error) {
+	switch val := v.(type) {
+	case undefinedValue:
+		return nil, nil
+	case []interface{}:
+		return val, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]interface{}, len(keys))
+		for i, k := range keys {
+			items[i] = k
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("render: cannot iterate over %T", v)
+	}
+}
+
+func execInclude(node *IncludeNode, ec *evalContext, out *strings.Builder) error {
+	if ec.include == nil {
+		return fmt.Errorf("render: {%% include %%} used but no IncludeResolver configured")
+	}
+	if ec.depth >= maxIncludeDepth {
+		return fmt.Errorf("render: include nesting exceeds %d, possible cycle", maxIncludeDepth)
+	}
+
+	nameVal, err := eval(node.Name, ec)
+	if err != nil {
+		return err
+	}
+	name, ok := nameVal.(string)
+	if !ok {
+		return fmt.Errorf("render: include name must be a string, got %T", nameVal)
+	}
+
+	body, err := ec.include.Resolve(ec.ctx, name)
+	if err != nil {
+		return fmt.Errorf("render: resolving include %q: %w", name, err)
+	}
+
+	nodes, err := Parse(body)
+	if err != nil {
+		return fmt.Errorf("render: parsing include %q: %w", name, err)
+	}
+
+	ec.depth++
+	err = execNodes(nodes, ec, out)
+	ec.depth--
+	return err
+}
+
+func eval(e Expr, ec *evalContext) (interface{}, error) {
+	switch expr := e.(type) {
+	case *LitExpr:
+		return expr.Value, nil
+
+	case *IdentExpr:
+		if v, ok := ec.sc.get(expr.Name); ok {
+			return v, nil
+		}
+		return undefinedValue{path: expr.Name}, nil
+
+	case *AttrExpr:
+		base, err := eval(expr.Base, ec)
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := base.(undefinedValue); ok {
+			return undefinedValue{path: u.path + "." + expr.Name}, nil
+		}
+		v, ok := access(base, expr.Name)
+		if !ok {
+			return undefinedValue{path: describe(expr.Base) + "." + expr.Name}, nil
+		}
+		return v, nil
+
+	case *IndexExpr:
+		base, err := eval(expr.Base, ec)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := eval(expr.Index, ec)
+		if err != nil {
+			return nil, err
+		}
+		if u, ok := base.(undefinedValue); ok {
+			return u, nil
+		}
+		v, ok := access(base, idx)
+		if !ok {
+			return undefinedValue{path: fmt.Sprintf("%s[%v]", describe(expr.Base), idx)}, nil
+		}
+		return v, nil
+
+	case *FilterExpr:
+		return evalFilter(expr, ec)
+
+	case *BinExpr:
+		return evalBin(expr, ec)
+
+	case *NotExpr:
+		v, err := eval(expr.X, ec)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(v), nil
+
+	default:
+		return nil, fmt.Errorf("render: unhandled expression type %T", e)
+	}
+}
+
+// describe renders a best-effort source-like path for an expression, used
+// to name an undefined reference in error messages and Preserve output.
+func describe(e Expr) string {
+	switch expr := e.(type) {
+	case *IdentExpr:
+		return expr.Name
+	case *AttrExpr:
+		return describe(expr.Base) + "." + expr.Name
+	case *IndexExpr:
+		return describe(expr.Base) + "[...]"
+	default:
+		return "<expr>"
+	}
+}
+
+// access looks up key (a string field name or a string/float64 index) on
+// base, which must be a map[string]interface{} or []interface{}.
+func access(base interface{}, key interface{}) (interface{}, bool) {
+	switch b := base.(type) {
+	case map[string]interface{}:
+		k, ok := key.(string)
+		if !ok {
+			return nil, false
+		}
+		v, ok := b[k]
+		return v, ok
+	case []interface{}:
+		idx, ok := toInt(key)
+		if !ok || idx < 0 || idx >= len(b) {
+			return nil, false
+		}
+		return b[idx], true
+	default:
+		return nil, false
+	}
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	case string:
+		i, err := strconv.Atoi(n)
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func evalBin(expr *BinExpr, ec *evalContext) (interface{}, error) {
+	left, err := eval(expr.Left, ec)
+	if err != nil {
+		return nil, err
+	}
+	right, err := eval(expr.Right, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.Op {
+	case tokAnd:
+		return truthy(left) && truthy(right), nil
+	case tokOr:
+		return truthy(left) || truthy(right), nil
+	case tokEqEq:
+		return valuesEqual(left, right), nil
+	case tokNeq:
+		return !valuesEqual(left, right), nil
+	case tokLt, tokLe, tokGt, tokGe:
+		lf, lok := toFloat(resolveUndefined(left))
+		rf, rok := toFloat(resolveUndefined(right))
+		if !lok || !rok {
+			return nil, fmt.Errorf("render: cannot compare non-numeric values %v and %v", left, right)
+		}
+		switch expr.Op {
+		case tokLt:
+			return lf < rf, nil
+		case tokLe:
+			return lf <= rf, nil
+		case tokGt:
+			return lf > rf, nil
+		default:
+			return lf >= rf, nil
+		}
+	default:
+		return nil, fmt.Errorf("render: unsupported operator")
+	}
+}
+
+// resolveUndefined maps an undefinedValue to nil so comparisons against it
+// behave like comparisons against a missing/zero value rather than panicking.
+func resolveUndefined(v interface{}) interface{} {
+	if _, ok := v.(undefinedValue); ok {
+		return nil
+	}
+	return v
+}
+
+func valuesEqual(a, b interface{}) bool {
+	a, b = resolveUndefined(a), resolveUndefined(b)
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			return af == bf
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func truthy(v interface{}) bool {
+	switch val := v.(type) {
+	case undefinedValue:
+		return false
+	case nil:
+		return false
+	case bool:
+		return val
+	case string:
+		return val != ""
+	case float64:
+		return val != 0
+	case int:
+		return val != 0
+	case []interface{}:
+		return len(val) > 0
+	case map[string]interface{}:
+		return len(val) > 0
+	default:
+		return true
+	}
+}
+
+// stringify converts v to its rendered string form, applying undefined to
+// an undefinedValue and src as its Preserve fallback.
+func stringify(v interface{}, ec *evalContext, src string) (string, error) {
+	u, ok := v.(undefinedValue)
+	if !ok {
+		return displayString(v), nil
+	}
+	switch ec.undefined {
+	case Empty:
+		return "", nil
+	case Preserve:
+		return "{{ " + src + " }}", nil
+	default:
+		return "", fmt.Errorf("render: undefined variable %q", u.path)
+	}
+}
+
+func displayString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,130 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// filterFunc applies a filter to value (the piped expression's result,
+// possibly an undefinedValue) with already-evaluated args.
+type filterFunc func(value interface{}, args []interface{}, ec *evalContext) (interface{}, error)
+
+var filters = map[string]filterFunc{
+	"default": filterDefault,
+	"upper":   filterUpper,
+	"lower":   filterLower,
+	"length":  filterLength,
+	"join":    filterJoin,
+	"tojson":  filterTojson,
+}
+
+func evalFilter(expr *FilterExpr, ec *evalContext) (interface{}, error) {
+	fn, ok := filters[expr.Name]
+	if !ok {
+		return nil, fmt.Errorf("render: unknown filter %q", expr.Name)
+	}
+
+	base, err := eval(expr.Base, ec)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, len(expr.Args))
+	for i, a := range expr.Args {
+		v, err := eval(a, ec)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = resolveUndefined(v)
+	}
+
+	return fn(base, args, ec)
+}
+
+// filterDefault returns args[0] if value is undefined (or nil), otherwise
+// value unchanged. Unlike every other filter, it must run before
+// UndefinedPolicy applies, since handling "might be missing" is its job.
+func filterDefault(value interface{}, args []interface{}, ec *evalContext) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("render: |default requires a fallback argument")
+	}
+	if _, ok := value.(undefinedValue); ok {
+		return args[0], nil
+	}
+	if value == nil {
+		return args[0], nil
+	}
+	return value, nil
+}
+
+func filterUpper(value interface{}, args []interface{}, ec *evalContext) (interface{}, error) {
+	s, err := filterInputString(value, ec)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToUpper(s), nil
+}
+
+func filterLower(value interface{}, args []interface{}, ec *evalContext) (interface{}, error) {
+	s, err := filterInputString(value, ec)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToLower(s), nil
+}
+
+func filterLength(value interface{}, args []interface{}, ec *evalContext) (interface{}, error) {
+	switch v := resolveUndefined(value).(type) {
+	case string:
+		return float64(len(v)), nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	case nil:
+		return float64(0), nil
+	default:
+		return nil, fmt.Errorf("render: |length doesn't support %T", value)
+	}
+}
+
+func filterJoin(value interface{}, args []interface{}, ec *evalContext) (interface{}, error) {
+	items, ok := resolveUndefined(value).([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("render: |join requires a list, got %T", value)
+	}
+	sep := ""
+	if len(args) > 0 {
+		sep = displayString(args[0])
+	}
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = displayString(item)
+	}
+	return strings.Join(parts, sep), nil
+}
+
+func filterTojson(value interface{}, args []interface{}, ec *evalContext) (interface{}, error) {
+	b, err := json.Marshal(resolveUndefined(value))
+	if err != nil {
+		return nil, fmt.Errorf("render: |tojson: %w", err)
+	}
+	return string(b), nil
+}
+
+// filterInputString resolves an undefined input per ec.undefined (rather
+// than erroring outright), so e.g. `{{ x|default("?")|upper }}` and
+// `{{ x|upper }}` under Empty/Preserve both still produce output.
+func filterInputString(value interface{}, ec *evalContext) (string, error) {
+	return stringify(value, ec, describeValue(value))
+}
+
+// describeValue is the Preserve placeholder used when a filter is applied
+// directly to an undefined value without a preceding {{ }} Src to reuse.
+func describeValue(value interface{}) string {
+	if u, ok := value.(undefinedValue); ok {
+		return u.path
+	}
+	return ""
+}
@@ -0,0 +1,176 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokDot
+	tokLBracket
+	tokRBracket
+	tokLParen
+	tokRParen
+	tokComma
+	tokPipe
+	tokEqEq
+	tokNeq
+	tokLt
+	tokLe
+	tokGt
+	tokGe
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type token struct {
+	kind tokenKind
+	text string // identifier/string text, or raw source for error messages
+	num  float64
+}
+
+var keywordTokens = map[string]tokenKind{
+	"and": tokAnd,
+	"or":  tokOr,
+	"not": tokNot,
+}
+
+// lexExpr tokenizes the expression language used inside {{ ... }} and the
+// condition/iterable parts of {% ... %} tags.
+func lexExpr(src string) ([]token, error) {
+	var toks []token
+	r := []rune(src)
+	i := 0
+	for i < len(r) {
+		c := r[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '.':
+			toks = append(toks, token{kind: tokDot})
+			i++
+		case c == '[':
+			toks = append(toks, token{kind: tokLBracket})
+			i++
+		case c == ']':
+			toks = append(toks, token{kind: tokRBracket})
+			i++
+		case c == '(':
+			toks = append(toks, token{kind: tokLParen})
+			i++
+		case c == ')':
+			toks = append(toks, token{kind: tokRParen})
+			i++
+		case c == ',':
+			toks = append(toks, token{kind: tokComma})
+			i++
+		case c == '|':
+			toks = append(toks, token{kind: tokPipe})
+			i++
+		case c == '=' && i+1 < len(r) && r[i+1] == '=':
+			toks = append(toks, token{kind: tokEqEq})
+			i += 2
+		case c == '!' && i+1 < len(r) && r[i+1] == '=':
+			toks = append(toks, token{kind: tokNeq})
+			i += 2
+		case c == '<' && i+1 < len(r) && r[i+1] == '=':
+			toks = append(toks, token{kind: tokLe})
+			i += 2
+		case c == '<':
+			toks = append(toks, token{kind: tokLt})
+			i++
+		case c == '>' && i+1 < len(r) && r[i+1] == '=':
+			toks = append(toks, token{kind: tokGe})
+			i += 2
+		case c == '>':
+			toks = append(toks, token{kind: tokGt})
+			i++
+		case c == '\'' || c == '"':
+			s, n, err := lexString(r[i:], c)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, text: s})
+			i += n
+		case c >= '0' && c <= '9':
+			s, n := lexNumber(r[i:])
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("render: invalid number %q", s)
+			}
+			toks = append(toks, token{kind: tokNumber, num: f})
+			i += n
+		case isIdentStart(c):
+			s, n := lexIdent(r[i:])
+			i += n
+			if kw, ok := keywordTokens[s]; ok {
+				toks = append(toks, token{kind: kw, text: s})
+			} else {
+				toks = append(toks, token{kind: tokIdent, text: s})
+			}
+		default:
+			return nil, fmt.Errorf("render: unexpected character %q in expression %q", string(c), src)
+		}
+	}
+	return toks, nil
+}
+
+func isIdentStart(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentCont(c rune) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func lexIdent(r []rune) (string, int) {
+	n := 0
+	for n < len(r) && isIdentCont(r[n]) {
+		n++
+	}
+	return string(r[:n]), n
+}
+
+func lexNumber(r []rune) (string, int) {
+	n := 0
+	for n < len(r) && (r[n] >= '0' && r[n] <= '9') {
+		n++
+	}
+	if n < len(r) && r[n] == '.' {
+		n++
+		for n < len(r) && (r[n] >= '0' && r[n] <= '9') {
+			n++
+		}
+	}
+	return string(r[:n]), n
+}
+
+// lexString reads a quoted string starting at r[0] (r[0] == quote),
+// returning the unescaped contents and the number of runes consumed
+// including both quotes.
+func lexString(r []rune, quote rune) (string, int, error) {
+	var b strings.Builder
+	i := 1
+	for i < len(r) {
+		c := r[i]
+		if c == '\\' && i+1 < len(r) {
+			b.WriteRune(r[i+1])
+			i += 2
+			continue
+		}
+		if c == quote {
+			return b.String(), i + 1, nil
+		}
+		b.WriteRune(c)
+		i++
+	}
+	return "", 0, fmt.Errorf("render: unterminated string literal")
+}
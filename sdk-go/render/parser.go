@@ -0,0 +1,453 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tmplTokKind int
+
+const (
+	tmplText tmplTokKind = iota
+	tmplOutput
+	tmplStmt
+)
+
+type tmplTok struct {
+	kind tmplTokKind
+	text string // raw text, or the trimmed content between the delimiters
+}
+
+// splitTemplate splits src into a flat sequence of text/output/statement
+// tokens, without interpreting nesting (that happens in parseNodes).
+func splitTemplate(src string) ([]tmplTok, error) {
+	var toks []tmplTok
+	for len(src) > 0 {
+		oi := strings.Index(src, "{{")
+		si := strings.Index(src, "{%")
+
+		idx, isOutput := -1, false
+		switch {
+		case oi == -1 && si == -1:
+			toks = append(toks, tmplTok{tmplText, src})
+			return toks, nil
+		case oi == -1:
+			idx, isOutput = si, false
+		case si == -1:
+			idx, isOutput = oi, true
+		case oi < si:
+			idx, isOutput = oi, true
+		default:
+			idx, isOutput = si, false
+		}
+
+		if idx > 0 {
+			toks = append(toks, tmplTok{tmplText, src[:idx]})
+		}
+		src = src[idx:]
+
+		closeTag := "%}"
+		if isOutput {
+			closeTag = "}}"
+		}
+		end := strings.Index(src, closeTag)
+		if end == -1 {
+			return nil, fmt.Errorf("render: unterminated %q tag", src[:2])
+		}
+
+		inner := strings.TrimSpace(src[2:end])
+		kind := tmplStmt
+		if isOutput {
+			kind = tmplOutput
+		}
+		toks = append(toks, tmplTok{kind, inner})
+		src = src[end+2:]
+	}
+	return toks, nil
+}
+
+// Parse parses template into a tree of Nodes, ready for execution.
+func Parse(template string) ([]Node, error) {
+	toks, err := splitTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	c := &tokCursor{toks: toks}
+	nodes, stop, err := parseNodes(c, nil)
+	if err != nil {
+		return nil, err
+	}
+	if stop != "" {
+		return nil, fmt.Errorf("render: unexpected {%% %s %%} tag", stop)
+	}
+	return nodes, nil
+}
+
+type tokCursor struct {
+	toks []tmplTok
+	pos  int
+}
+
+// stmtKeyword splits a statement tag's trimmed content into its leading
+// keyword and the remainder, e.g. "if x > 1" -> ("if", "x > 1").
+func stmtKeyword(s string) (kw, rest string) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexAny(s, " \t\n")
+	if i == -1 {
+		return s, ""
+	}
+	return s[:i], strings.TrimSpace(s[i+1:])
+}
+
+// parseNodes consumes tokens from c until EOF or a statement tag whose
+// keyword is in stop, returning the parsed nodes and which stop keyword
+// was hit ("" at EOF). The stop token itself is left unconsumed.
+func parseNodes(c *tokCursor, stop map[string]bool) ([]Node, string, error) {
+	var nodes []Node
+	for c.pos < len(c.toks) {
+		t := c.toks[c.pos]
+		switch t.kind {
+		case tmplText:
+			nodes = append(nodes, &TextNode{Text: t.text})
+			c.pos++
+
+		case tmplOutput:
+			expr, err := parseExprSrc(t.text)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, &OutputNode{Expr: expr, Src: t.text})
+			c.pos++
+
+		case tmplStmt:
+			kw, rest := stmtKeyword(t.text)
+			if stop[kw] {
+				return nodes, kw, nil
+			}
+			switch kw {
+			case "if":
+				node, err := parseIf(c)
+				if err != nil {
+					return nil, "", err
+				}
+				nodes = append(nodes, node)
+			case "for":
+				node, err := parseFor(c)
+				if err != nil {
+					return nil, "", err
+				}
+				nodes = append(nodes, node)
+			case "set":
+				name, exprSrc, err := splitSetStmt(rest)
+				if err != nil {
+					return nil, "", err
+				}
+				expr, err := parseExprSrc(exprSrc)
+				if err != nil {
+					return nil, "", err
+				}
+				nodes = append(nodes, &SetNode{Name: name, Expr: expr})
+				c.pos++
+			case "include":
+				expr, err := parseExprSrc(rest)
+				if err != nil {
+					return nil, "", err
+				}
+				nodes = append(nodes, &IncludeNode{Name: expr})
+				c.pos++
+			default:
+				return nil, "", fmt.Errorf("render: unknown tag {%% %s %%}", t.text)
+			}
+		}
+	}
+	return nodes, "", nil
+}
+
+func parseIf(c *tokCursor) (*IfNode, error) {
+	_, rest := stmtKeyword(c.toks[c.pos].text) // "if"
+	cond, err := parseExprSrc(rest)
+	if err != nil {
+		return nil, err
+	}
+	c.pos++
+
+	node := &IfNode{}
+	for {
+		body, stop, err := parseNodes(c, map[string]bool{"elif": true, "else": true, "endif": true})
+		if err != nil {
+			return nil, err
+		}
+		node.Branches = append(node.Branches, ifBranch{Cond: cond, Body: body})
+		if stop == "" {
+			return nil, fmt.Errorf("render: missing {%% endif %%}")
+		}
+
+		_, stopRest := stmtKeyword(c.toks[c.pos].text)
+		switch stop {
+		case "elif":
+			cond, err = parseExprSrc(stopRest)
+			if err != nil {
+				return nil, err
+			}
+			c.pos++
+			continue
+		case "else":
+			c.pos++
+			elseBody, stop2, err := parseNodes(c, map[string]bool{"endif": true})
+			if err != nil {
+				return nil, err
+			}
+			if stop2 != "endif" {
+				return nil, fmt.Errorf("render: missing {%% endif %%}")
+			}
+			node.Else = elseBody
+			c.pos++
+			return node, nil
+		case "endif":
+			c.pos++
+			return node, nil
+		}
+	}
+}
+
+func parseFor(c *tokCursor) (*ForNode, error) {
+	_, rest := stmtKeyword(c.toks[c.pos].text) // "for"
+	varName, iterSrc, err := splitForHeader(rest)
+	if err != nil {
+		return nil, err
+	}
+	iterExpr, err := parseExprSrc(iterSrc)
+	if err != nil {
+		return nil, err
+	}
+	c.pos++
+
+	body, stop, err := parseNodes(c, map[string]bool{"endfor": true})
+	if err != nil {
+		return nil, err
+	}
+	if stop != "endfor" {
+		return nil, fmt.Errorf("render: missing {%% endfor %%}")
+	}
+	c.pos++
+
+	return &ForNode{Var: varName, Iter: iterExpr, Body: body}, nil
+}
+
+func splitForHeader(rest string) (varName, iterSrc string, err error) {
+	i := strings.Index(rest, " in ")
+	if i == -1 {
+		return "", "", fmt.Errorf("render: malformed for tag %q, want \"x in xs\"", rest)
+	}
+	return strings.TrimSpace(rest[:i]), strings.TrimSpace(rest[i+len(" in "):]), nil
+}
+
+func splitSetStmt(rest string) (name, exprSrc string, err error) {
+	i := strings.Index(rest, "=")
+	if i == -1 {
+		return "", "", fmt.Errorf("render: malformed set tag %q, want \"name = expr\"", rest)
+	}
+	return strings.TrimSpace(rest[:i]), strings.TrimSpace(rest[i+1:]), nil
+}
+
+// --- Expression parsing (recursive descent, lowest to highest precedence:
+// or, and, not, comparison, pipe/filter, primary with attr/index trailers) ---
+
+type exprParser struct {
+	toks []token
+	pos  int
+}
+
+func parseExprSrc(src string) (Expr, error) {
+	toks, err := lexExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("render: unexpected token after expression %q", src)
+	}
+	return e, nil
+}
+
+func (p *exprParser) peek() tokenKind {
+	if p.pos >= len(p.toks) {
+		return tokEOF
+	}
+	return p.toks[p.pos].kind
+}
+
+func (p *exprParser) next() token {
+	t := p.toks[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *exprParser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinExpr{Op: tokOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == tokAnd {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinExpr{Op: tokAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (Expr, error) {
+	if p.peek() == tokNot {
+		p.next()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{X: x}, nil
+	}
+	return p.parseComparison()
+}
+
+var comparisonOps = map[tokenKind]bool{
+	tokEqEq: true, tokNeq: true, tokLt: true, tokLe: true, tokGt: true, tokGe: true,
+}
+
+func (p *exprParser) parseComparison() (Expr, error) {
+	left, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if comparisonOps[p.peek()] {
+		op := p.next().kind
+		right, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return &BinExpr{Op: op, Left: left, Right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parsePipe() (Expr, error) {
+	left, err := p.parseTrailers()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == tokPipe {
+		p.next()
+		if p.peek() != tokIdent {
+			return nil, fmt.Errorf("render: expected filter name after |")
+		}
+		name := p.next().text
+
+		var args []Expr
+		if p.peek() == tokLParen {
+			p.next()
+			for p.peek() != tokRParen {
+				arg, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if p.peek() == tokComma {
+					p.next()
+					continue
+				}
+				break
+			}
+			if p.peek() != tokRParen {
+				return nil, fmt.Errorf("render: expected ) to close filter arguments")
+			}
+			p.next()
+		}
+		left = &FilterExpr{Base: left, Name: name, Args: args}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseTrailers() (Expr, error) {
+	atom, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case tokDot:
+			p.next()
+			if p.peek() != tokIdent {
+				return nil, fmt.Errorf("render: expected identifier after .")
+			}
+			atom = &AttrExpr{Base: atom, Name: p.next().text}
+		case tokLBracket:
+			p.next()
+			idx, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if p.peek() != tokRBracket {
+				return nil, fmt.Errorf("render: expected ] to close index")
+			}
+			p.next()
+			atom = &IndexExpr{Base: atom, Index: idx}
+		default:
+			return atom, nil
+		}
+	}
+}
+
+func (p *exprParser) parseAtom() (Expr, error) {
+	switch p.peek() {
+	case tokNumber:
+		return &LitExpr{Value: p.next().num}, nil
+	case tokString:
+		return &LitExpr{Value: p.next().text}, nil
+	case tokLParen:
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != tokRParen {
+			return nil, fmt.Errorf("render: expected ) to close (")
+		}
+		p.next()
+		return e, nil
+	case tokIdent:
+		name := p.next().text
+		switch name {
+		case "true":
+			return &LitExpr{Value: true}, nil
+		case "false":
+			return &LitExpr{Value: false}, nil
+		case "none", "null":
+			return &LitExpr{Value: nil}, nil
+		default:
+			return &IdentExpr{Name: name}, nil
+		}
+	default:
+		return nil, fmt.Errorf("render: unexpected token in expression")
+	}
+}
@@ -0,0 +1,81 @@
+// Package render is a pure-Go, offline implementation of the Jinja2
+// subset the Promptory server uses for prompt rendering: {{ expr }}
+// output with dotted/index access and filters (|default, |upper, |lower,
+// |length, |join, |tojson), {% if/elif/else/endif %}, {% for x in xs %}
+// with loop.index/loop.last, {% set %}, and {% include "name" %}.
+//
+// It exists so callers can render prompts without a network round trip
+// to Client.Render (see Client.RenderOffline), including in tests and
+// air-gapped deployments. Rendering is deterministic: map iteration
+// (e.g. {% for k in a_dict %}) is in sorted key order, and the handling
+// of a variable missing from Vars is controlled explicitly via
+// UndefinedPolicy rather than left to map-iteration or zero-value luck.
+package render
+
+import (
+	"context"
+	"strings"
+)
+
+// UndefinedPolicy controls what happens when a template references a
+// variable, attribute, or index that isn't present in Vars.
+type UndefinedPolicy int
+
+const (
+	// Strict makes an undefined reference a render error.
+	Strict UndefinedPolicy = iota
+	// Empty renders an undefined reference as an empty string.
+	Empty
+	// Preserve renders an undefined reference as its original source
+	// text (e.g. "{{ user.name }}"), so a partially-populated template
+	// can be rendered again later once more variables are known.
+	Preserve
+)
+
+// IncludeResolver resolves the body of a named template for {% include %},
+// e.g. by fetching another prompt by name. Client.RenderOffline's default
+// resolver looks names up via Client.GetByName (sharing Client's cache).
+type IncludeResolver interface {
+	Resolve(ctx context.Context, name string) (body string, err error)
+}
+
+// Options configures a Render call.
+type Options struct {
+	// Vars supplies the top-level template variables.
+	Vars map[string]interface{}
+
+	// Includes resolves {% include "name" %} tags. If nil, a template
+	// using include fails with an error.
+	Includes IncludeResolver
+
+	// Undefined controls how a missing variable/attribute/index is
+	// rendered (default: Strict).
+	Undefined UndefinedPolicy
+}
+
+// Render parses and executes template against opts, returning the
+// rendered output.
+func Render(ctx context.Context, template string, opts Options) (string, error) {
+	nodes, err := Parse(template)
+	if err != nil {
+		return "", err
+	}
+
+	sc := newScope(nil)
+	for k, v := range opts.Vars {
+		sc.set(k, v)
+	}
+
+	ec := &evalContext{
+		ctx:       ctx,
+		sc:        sc,
+		undefined: opts.Undefined,
+		include:   opts.Includes,
+	}
+
+	var out strings.Builder
+	if err := execNodes(nodes, ec, &out); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
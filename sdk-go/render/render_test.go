@@ -0,0 +1,146 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func renderStr(t *testing.T, tmpl string, vars map[string]interface{}, policy UndefinedPolicy) string {
+	t.Helper()
+	out, err := Render(context.Background(), tmpl, Options{Vars: vars, Undefined: policy})
+	if err != nil {
+		t.Fatalf("Render(%q): %v", tmpl, err)
+	}
+	return out
+}
+
+func TestRender_Output(t *testing.T) {
+	got := renderStr(t, "Hello {{ name }}!", map[string]interface{}{"name": "Alice"}, Empty)
+	if want := "Hello Alice!"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRender_DottedAndIndexAccess(t *testing.T) {
+	vars := map[string]interface{}{
+		"user": map[string]interface{}{"name": "Bob"},
+		"tags": []interface{}{"a", "b", "c"},
+	}
+	got := renderStr(t, "{{ user.name }}/{{ tags[1] }}", vars, Strict)
+	if want := "Bob/b"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRender_Filters(t *testing.T) {
+	vars := map[string]interface{}{"name": "alice", "tags": []interface{}{"x", "y"}}
+	got := renderStr(t, "{{ name|upper }} [{{ tags|join(\", \") }}] len={{ tags|length }}", vars, Strict)
+	if want := "ALICE [x, y] len=2"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRender_DefaultFilterSuppressesUndefined(t *testing.T) {
+	got := renderStr(t, "{{ missing|default(\"fallback\") }}", nil, Strict)
+	if want := "fallback"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRender_Tojson(t *testing.T) {
+	vars := map[string]interface{}{"m": map[string]interface{}{"b": 1, "a": 2}}
+	got := renderStr(t, "{{ m|tojson }}", vars, Strict)
+	if want := `{"a":2,"b":1}`; got != want {
+		t.Errorf("got %q, want %q (map keys must be sorted)", got, want)
+	}
+}
+
+func TestRender_IfElifElse(t *testing.T) {
+	tmpl := "{% if score >= 90 %}A{% elif score >= 80 %}B{% else %}C{% endif %}"
+	for score, want := range map[float64]string{95: "A", 85: "B", 50: "C"} {
+		got := renderStr(t, tmpl, map[string]interface{}{"score": score}, Strict)
+		if got != want {
+			t.Errorf("score=%v: got %q, want %q", score, got, want)
+		}
+	}
+}
+
+func TestRender_ForLoopVars(t *testing.T) {
+	vars := map[string]interface{}{"items": []interface{}{"a", "b", "c"}}
+	tmpl := "{% for x in items %}{{ loop.index }}:{{ x }}{% if not loop.last %},{% endif %}{% endfor %}"
+	got := renderStr(t, tmpl, vars, Strict)
+	if want := "1:a,2:b,3:c"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRender_ForOverMapIsSortedByKey(t *testing.T) {
+	vars := map[string]interface{}{"m": map[string]interface{}{"z": 1, "a": 2, "m": 3}}
+	got := renderStr(t, "{% for k in m %}{{ k }}{% endfor %}", vars, Strict)
+	if want := "amz"; got != want {
+		t.Errorf("got %q, want %q (must be deterministic sorted order)", got, want)
+	}
+}
+
+func TestRender_SetReassignsScope(t *testing.T) {
+	got := renderStr(t, "{% set x = 1 %}{{ x }}", nil, Strict)
+	if want := "1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRender_UndefinedPolicies(t *testing.T) {
+	const tmpl = "{{ missing }}"
+
+	if _, err := Render(context.Background(), tmpl, Options{Undefined: Strict}); err == nil {
+		t.Error("Strict: expected an error for an undefined variable, got nil")
+	}
+
+	if got := renderStr(t, tmpl, nil, Empty); got != "" {
+		t.Errorf("Empty: got %q, want empty string", got)
+	}
+
+	if got := renderStr(t, tmpl, nil, Preserve); got != "{{ missing }}" {
+		t.Errorf("Preserve: got %q, want %q", got, "{{ missing }}")
+	}
+}
+
+type mapIncludeResolver map[string]string
+
+func (r mapIncludeResolver) Resolve(ctx context.Context, name string) (string, error) {
+	body, ok := r[name]
+	if !ok {
+		return "", errors.New("no such include: " + name)
+	}
+	return body, nil
+}
+
+func TestRender_Include(t *testing.T) {
+	out, err := Render(context.Background(), "Header: {% include \"partials/footer\" %}", Options{
+		Vars:     map[string]interface{}{"year": float64(2026)},
+		Includes: mapIncludeResolver{"partials/footer": "(c) {{ year }}"},
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if want := "Header: (c) 2026"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestRender_IncludeCycleIsBounded(t *testing.T) {
+	_, err := Render(context.Background(), "{% include \"a\" %}", Options{
+		Includes: mapIncludeResolver{"a": "{% include \"a\" %}"},
+	})
+	if err == nil {
+		t.Error("expected an error for a self-including template, got nil")
+	}
+}
+
+func TestRender_MissingIncludeResolverErrors(t *testing.T) {
+	_, err := Render(context.Background(), "{% include \"a\" %}", Options{})
+	if err == nil {
+		t.Error("expected an error when no IncludeResolver is configured, got nil")
+	}
+}
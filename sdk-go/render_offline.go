@@ -0,0 +1,51 @@
+package promptory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/winzeler/promptory/sdk-go/render"
+)
+
+// clientIncludeResolver is the default render.IncludeResolver for
+// RenderOffline: it resolves a {% include "org/app/name" %} tag via
+// Client.GetByName, so an included prompt is fetched (and cached) exactly
+// like any other prompt.
+type clientIncludeResolver struct {
+	client *Client
+}
+
+func (r *clientIncludeResolver) Resolve(ctx context.Context, name string) (string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("promptory: include name %q must be \"org/app/name\"", name)
+	}
+	p, err := r.client.GetByName(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return "", err
+	}
+	return p.Body, nil
+}
+
+// RenderOffline renders prompt.Body locally using the promptory/render
+// engine (the Jinja2 subset the server supports: expressions, filters,
+// if/for, set, include), without a network round trip to Render.
+// {% include %} tags are always resolved via Client.GetByName, sharing
+// Client's cache; call render.Render directly to use a custom
+// render.IncludeResolver.
+//
+// A variable missing from vars renders as an empty string
+// (render.Empty); call render.Render directly for Strict or Preserve
+// undefined handling.
+func (c *Client) RenderOffline(ctx context.Context, prompt *Prompt, vars map[string]interface{}) (*RenderResult, error) {
+	body, err := render.Render(ctx, prompt.Body, render.Options{
+		Vars:      vars,
+		Includes:  &clientIncludeResolver{client: c},
+		Undefined: render.Empty,
+	})
+	if err != nil {
+		return nil, &PromptoryError{Message: "render: " + err.Error()}
+	}
+	return &RenderResult{RenderedBody: body}, nil
+}
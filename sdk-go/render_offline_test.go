@@ -0,0 +1,89 @@
+package promptory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_RenderOffline(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(samplePrompt)
+	})
+	defer server.Close()
+
+	prompt := samplePrompt
+	prompt.Body = "Hello {{ name }}, welcome to {{ place }}."
+
+	result, err := client.RenderOffline(context.Background(), &prompt, map[string]interface{}{
+		"name":  "Alice",
+		"place": "Wonderland",
+	})
+	if err != nil {
+		t.Fatalf("RenderOffline: %v", err)
+	}
+	if want := "Hello Alice, welcome to Wonderland."; result.RenderedBody != want {
+		t.Errorf("got %q, want %q", result.RenderedBody, want)
+	}
+}
+
+func TestClient_RenderOffline_MissingVarRendersEmpty(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(samplePrompt)
+	})
+	defer server.Close()
+
+	prompt := samplePrompt
+	prompt.Body = "Hi {{ name }}!"
+
+	result, err := client.RenderOffline(context.Background(), &prompt, nil)
+	if err != nil {
+		t.Fatalf("RenderOffline: %v", err)
+	}
+	if want := "Hi !"; result.RenderedBody != want {
+		t.Errorf("got %q, want %q", result.RenderedBody, want)
+	}
+}
+
+func TestClient_RenderOffline_Include(t *testing.T) {
+	footer := samplePrompt
+	footer.Name = "footer"
+	footer.Body = "(c) Acme"
+
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/by-name/testorg/testapp/footer") {
+			json.NewEncoder(w).Encode(footer)
+			return
+		}
+		json.NewEncoder(w).Encode(samplePrompt)
+	})
+	defer server.Close()
+
+	prompt := samplePrompt
+	prompt.Body = "Header {% include \"testorg/testapp/footer\" %}"
+
+	result, err := client.RenderOffline(context.Background(), &prompt, nil)
+	if err != nil {
+		t.Fatalf("RenderOffline: %v", err)
+	}
+	if want := "Header (c) Acme"; result.RenderedBody != want {
+		t.Errorf("got %q, want %q", result.RenderedBody, want)
+	}
+}
+
+func TestClient_RenderOffline_InvalidIncludeName(t *testing.T) {
+	server, client := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(samplePrompt)
+	})
+	defer server.Close()
+
+	prompt := samplePrompt
+	prompt.Body = "{% include \"not-a-valid-name\" %}"
+
+	_, err := client.RenderOffline(context.Background(), &prompt, nil)
+	if err == nil {
+		t.Fatal("expected an error for a malformed include name")
+	}
+}
@@ -0,0 +1,207 @@
+package promptory
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// PromptSignature is the JWS signature metadata a Promptory server attaches
+// to a prompt when cryptographic verification is enabled (the "v2" fetch
+// path, see ClientOptions.VerifyKeys). Sig is the base64url-encoded JWS
+// signature over the prompt's canonical payload; Alg and Kid identify how,
+// and with which key, to verify it; IAT is the signing time as a Unix
+// timestamp.
+type PromptSignature struct {
+	Sig string `json:"sig"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	IAT int64  `json:"iat"`
+}
+
+// KeySet resolves a verification public key by kid, so signature
+// verification can support key rotation instead of a single hardcoded key.
+type KeySet interface {
+	Key(kid string) (crypto.PublicKey, error)
+}
+
+// staticKeySet is a KeySet backed by a fixed map of kid -> public key.
+type staticKeySet map[string]crypto.PublicKey
+
+// NewStaticKeySet creates a KeySet from a fixed map of kid to public key
+// (*rsa.PublicKey, *ecdsa.PublicKey, or ed25519.PublicKey, matching the
+// "alg" each key is used with). Use this for ClientOptions.VerifyKeys when
+// the server signs with a small, rarely-rotated set of keys known up front.
+func NewStaticKeySet(keys map[string]crypto.PublicKey) KeySet {
+	return staticKeySet(keys)
+}
+
+func (s staticKeySet) Key(kid string) (crypto.PublicKey, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, fmt.Errorf("promptory: no verification key for kid %q", kid)
+	}
+	return key, nil
+}
+
+// promptSignatureError wraps a prompt signature verification failure. It's
+// a dedicated type, rather than a *PromptoryError with StatusCode 0, so
+// errors.Is(err, ErrPromptSignature) can't collide with this package's
+// other zero-status PromptoryErrors (e.g. validation errors).
+type promptSignatureError struct {
+	reason string
+	err    error
+}
+
+func (e *promptSignatureError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("promptory: prompt signature verification failed: %s: %v", e.reason, e.err)
+	}
+	return fmt.Sprintf("promptory: prompt signature verification failed: %s", e.reason)
+}
+
+// Is supports errors.Is matching against ErrPromptSignature regardless of
+// the specific reason verification failed.
+func (e *promptSignatureError) Is(target error) bool {
+	_, ok := target.(*promptSignatureError)
+	return ok
+}
+
+func (e *promptSignatureError) Unwrap() error {
+	return e.err
+}
+
+// ErrPromptSignature indicates a prompt's signature failed verification.
+// Use errors.Is to detect it.
+var ErrPromptSignature = &promptSignatureError{reason: "verification failed"}
+
+// Verify checks p.Signature against keyset, reconstructing the canonical
+// payload Promptory signs (id, org/app/name, version, git_sha, body hash)
+// and verifying it as a JWS using the algorithm named in p.Signature.Alg
+// (RS256, ES256, or EdDSA). It returns nil if p has no Signature (nothing
+// to verify) or an error satisfying errors.Is(err, ErrPromptSignature)
+// otherwise.
+//
+// Use this to revalidate a prompt loaded from a local cache or disk
+// snapshot. Get and GetByName call it automatically when
+// ClientOptions.VerifyKeys is set, and additionally treat a missing
+// Signature as a verification failure in that case.
+func (p *Prompt) Verify(keyset KeySet) error {
+	if p.Signature == nil {
+		return nil
+	}
+	return verifyPromptSignature(p, keyset)
+}
+
+// verifyFetchedPrompt checks p against c.verifyKeys for any path that
+// populates the cache from the network (Get/GetByName's doFetch,
+// GetMany/PrefetchByName's fetchBatch, Watch/WatchByName's
+// applyWatchFrame), so a signed deployment can't have its cache poisoned
+// by an unverified prompt regardless of which of those paths fetched it.
+// It is a no-op when c.verifyKeys is nil, and unlike Prompt.Verify, it
+// treats a missing Signature as a failure rather than skipping
+// verification, since a signed deployment should never serve an unsigned
+// prompt.
+func (c *Client) verifyFetchedPrompt(p *Prompt) error {
+	if c.verifyKeys == nil {
+		return nil
+	}
+	if p.Signature == nil {
+		return &promptSignatureError{reason: "server did not return a signature"}
+	}
+	return verifyPromptSignature(p, c.verifyKeys)
+}
+
+func verifyPromptSignature(p *Prompt, keyset KeySet) error {
+	sig := p.Signature
+
+	key, err := keyset.Key(sig.Kid)
+	if err != nil {
+		return &promptSignatureError{reason: "resolving key for kid " + sig.Kid, err: err}
+	}
+
+	header, err := json.Marshal(struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{sig.Alg, sig.Kid})
+	if err != nil {
+		return &promptSignatureError{reason: "encoding JWS header", err: err}
+	}
+
+	signingInput := b64URLEncode(header) + "." + b64URLEncode(canonicalPayload(p))
+
+	sigBytes, err := base64.RawURLEncoding.DecodeString(sig.Sig)
+	if err != nil {
+		return &promptSignatureError{reason: "decoding signature", err: err}
+	}
+
+	if err := verifyJWS(sig.Alg, key, []byte(signingInput), sigBytes); err != nil {
+		return &promptSignatureError{reason: "alg " + sig.Alg, err: err}
+	}
+	return nil
+}
+
+// canonicalPayload reconstructs the deterministic payload Promptory signs
+// for a prompt: its identity, version, git SHA, and a hash of its body, so
+// verification doesn't depend on the server re-sending the exact signed
+// bytes over the wire.
+func canonicalPayload(p *Prompt) []byte {
+	gitSHA := ""
+	if p.GitSHA != nil {
+		gitSHA = *p.GitSHA
+	}
+	bodyHash := sha256.Sum256([]byte(p.Body))
+	return []byte(fmt.Sprintf("%s|%s/%s/%s|%s|%s|%s",
+		p.ID, p.Org, p.App, p.Name, p.Version, gitSHA, hex.EncodeToString(bodyHash[:])))
+}
+
+func b64URLEncode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// verifyJWS verifies signingInput against sig using the named JWS
+// algorithm and key, per RFC 7518.
+func verifyJWS(alg string, key crypto.PublicKey, signingInput, sig []byte) error {
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not an RSA public key")
+		}
+		hashed := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], sig)
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not an ECDSA public key")
+		}
+		if len(sig) != 64 {
+			return fmt.Errorf("ES256 signature must be 64 bytes, got %d", len(sig))
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		hashed := sha256.Sum256(signingInput)
+		if !ecdsa.Verify(pub, hashed[:], r, s) {
+			return fmt.Errorf("ECDSA signature verification failed")
+		}
+		return nil
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not an Ed25519 public key")
+		}
+		if !ed25519.Verify(pub, signingInput, sig) {
+			return fmt.Errorf("Ed25519 signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+}
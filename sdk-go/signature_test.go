@@ -0,0 +1,313 @@
+package promptory
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// signPrompt signs p's canonical payload with priv under kid/alg and
+// returns a copy of p with Signature populated, mirroring what a real
+// Promptory server would attach to a "v2" response.
+func signPrompt(t *testing.T, p Prompt, kid, alg string, sign func(signingInput []byte) []byte) Prompt {
+	t.Helper()
+
+	header, err := json.Marshal(struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{alg, kid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingInput := b64URLEncode(header) + "." + b64URLEncode(canonicalPayload(&p))
+
+	p.Signature = &PromptSignature{
+		Sig: base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput))),
+		Alg: alg,
+		Kid: kid,
+		IAT: 1700000000,
+	}
+	return p
+}
+
+func TestPrompt_Verify_EdDSA(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := signPrompt(t, samplePrompt, "key-1", "EdDSA", func(in []byte) []byte {
+		return ed25519.Sign(priv, in)
+	})
+
+	keyset := NewStaticKeySet(map[string]crypto.PublicKey{"key-1": pub})
+	if err := signed.Verify(keyset); err != nil {
+		t.Errorf("Verify() = %v, want nil", err)
+	}
+}
+
+func TestPrompt_Verify_RS256(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := signPrompt(t, samplePrompt, "key-1", "RS256", func(in []byte) []byte {
+		hashed := sha256.Sum256(in)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, hashed[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	})
+
+	keyset := NewStaticKeySet(map[string]crypto.PublicKey{"key-1": &priv.PublicKey})
+	if err := signed.Verify(keyset); err != nil {
+		t.Errorf("Verify() = %v, want nil", err)
+	}
+}
+
+func TestPrompt_Verify_ES256(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := signPrompt(t, samplePrompt, "key-1", "ES256", func(in []byte) []byte {
+		hashed := sha256.Sum256(in)
+		r, s, err := ecdsa.Sign(rand.Reader, priv, hashed[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig := make([]byte, 64)
+		rBytes := r.Bytes()
+		sBytes := s.Bytes()
+		copy(sig[32-len(rBytes):32], rBytes)
+		copy(sig[64-len(sBytes):64], sBytes)
+		return sig
+	})
+
+	keyset := NewStaticKeySet(map[string]crypto.PublicKey{"key-1": &priv.PublicKey})
+	if err := signed.Verify(keyset); err != nil {
+		t.Errorf("Verify() = %v, want nil", err)
+	}
+}
+
+func TestPrompt_Verify_TamperedBodyFails(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := signPrompt(t, samplePrompt, "key-1", "EdDSA", func(in []byte) []byte {
+		return ed25519.Sign(priv, in)
+	})
+	signed.Body = signed.Body + " tampered"
+
+	keyset := NewStaticKeySet(map[string]crypto.PublicKey{"key-1": pub})
+	err = signed.Verify(keyset)
+	if !errors.Is(err, ErrPromptSignature) {
+		t.Errorf("Verify() = %v, want ErrPromptSignature", err)
+	}
+}
+
+func TestPrompt_Verify_UnknownKidFails(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := signPrompt(t, samplePrompt, "key-1", "EdDSA", func(in []byte) []byte {
+		return ed25519.Sign(priv, in)
+	})
+
+	keyset := NewStaticKeySet(map[string]crypto.PublicKey{"other-key": pub})
+	if err := signed.Verify(keyset); !errors.Is(err, ErrPromptSignature) {
+		t.Errorf("Verify() = %v, want ErrPromptSignature", err)
+	}
+}
+
+func TestPrompt_Verify_NoSignatureIsNoOp(t *testing.T) {
+	p := samplePrompt
+	p.Signature = nil
+	if err := p.Verify(NewStaticKeySet(nil)); err != nil {
+		t.Errorf("Verify() = %v, want nil for an unsigned prompt", err)
+	}
+}
+
+func TestClient_Get_VerifiesSignatureOnV2Path(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		signed := signPrompt(t, samplePrompt, "key-1", "EdDSA", func(in []byte) []byte {
+			return ed25519.Sign(priv, in)
+		})
+		json.NewEncoder(w).Encode(signed)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:    server.URL,
+		APIKey:     "pm_test_key",
+		VerifyKeys: NewStaticKeySet(map[string]crypto.PublicKey{"key-1": pub}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prompt, err := client.Get(context.Background(), samplePrompt.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt.Signature == nil {
+		t.Error("expected the cached prompt to retain its Signature")
+	}
+	if !strings.HasPrefix(gotPath, "/api/v2/") {
+		t.Errorf("request path = %q, want the /api/v2/ fetch path", gotPath)
+	}
+}
+
+func TestClient_Get_MissingSignatureFailsWhenVerifyKeysSet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(samplePrompt) // unsigned
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:    server.URL,
+		APIKey:     "pm_test_key",
+		VerifyKeys: NewStaticKeySet(nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Get(context.Background(), samplePrompt.ID)
+	if !errors.Is(err, ErrPromptSignature) {
+		t.Errorf("Get() err = %v, want ErrPromptSignature", err)
+	}
+}
+
+func TestGetMany_VerifiesSignatureOnV2Path(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var req batchRequestBody
+		json.NewDecoder(r.Body).Decode(&req)
+		results := make([]batchResponseEntry, len(req.Prompts))
+		for i, p := range req.Prompts {
+			prompt := samplePrompt
+			prompt.ID = p.ID
+			signed := signPrompt(t, prompt, "key-1", "EdDSA", func(in []byte) []byte {
+				return ed25519.Sign(priv, in)
+			})
+			results[i] = batchResponseEntry{Prompt: &signed, ETag: "v1"}
+		}
+		json.NewEncoder(w).Encode(batchResponseBody{Results: results})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:    server.URL,
+		APIKey:     "pm_test_key",
+		VerifyKeys: NewStaticKeySet(map[string]crypto.PublicKey{"key-1": pub}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetMany(context.Background(), []string{"p1", "p2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d prompts, want 2", len(got))
+	}
+	if !strings.HasPrefix(gotPath, "/api/v2/") {
+		t.Errorf("request path = %q, want the /api/v2/ batch path", gotPath)
+	}
+}
+
+func TestGetMany_MissingSignatureFailsWhenVerifyKeysSet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req batchRequestBody
+		json.NewDecoder(r.Body).Decode(&req)
+		results := make([]batchResponseEntry, len(req.Prompts))
+		for i, p := range req.Prompts {
+			prompt := samplePrompt
+			prompt.ID = p.ID
+			results[i] = batchResponseEntry{Prompt: &prompt} // unsigned
+		}
+		json.NewEncoder(w).Encode(batchResponseBody{Results: results})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:    server.URL,
+		APIKey:     "pm_test_key",
+		VerifyKeys: NewStaticKeySet(nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.GetMany(context.Background(), []string{"p1"})
+	if !errors.Is(err, ErrPromptSignature) {
+		t.Errorf("GetMany() err = %v, want ErrPromptSignature", err)
+	}
+}
+
+func TestPrefetchByName_MissingSignatureFailsWhenVerifyKeysSet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req batchRequestBody
+		json.NewDecoder(r.Body).Decode(&req)
+		results := make([]batchResponseEntry, len(req.Prompts))
+		for i, p := range req.Prompts {
+			prompt := samplePrompt
+			prompt.Org, prompt.App, prompt.Name = p.Org, p.App, p.Name
+			results[i] = batchResponseEntry{Prompt: &prompt} // unsigned
+		}
+		json.NewEncoder(w).Encode(batchResponseBody{Results: results})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:    server.URL,
+		APIKey:     "pm_test_key",
+		VerifyKeys: NewStaticKeySet(nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.PrefetchByName(context.Background(), []PromptRef{
+		{Org: "myorg", App: "myapp", Name: "greeting"},
+	})
+	if !errors.Is(err, ErrPromptSignature) {
+		t.Errorf("PrefetchByName() err = %v, want ErrPromptSignature", err)
+	}
+	if stats := client.CacheStats(); stats.Size != 0 {
+		t.Errorf("CacheStats().Size = %d, want 0 (unverified prompt must not be cached)", stats.Size)
+	}
+}
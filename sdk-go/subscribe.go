@@ -0,0 +1,199 @@
+package promptory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// CacheEventType identifies the kind of change reported by a CacheEvent.
+type CacheEventType string
+
+const (
+	// CacheEventUpdated indicates a prompt's body or metadata changed.
+	CacheEventUpdated CacheEventType = "prompt.updated"
+
+	// CacheEventDeleted indicates a prompt was deleted.
+	CacheEventDeleted CacheEventType = "prompt.deleted"
+)
+
+// CacheEvent is a single change pushed by the server, via Client.Subscribe
+// or Client.HandleWebhook, used to invalidate (and optionally pre-warm)
+// the matching Client cache entries.
+type CacheEvent struct {
+	Type    CacheEventType `json:"type"`
+	ID      string         `json:"id"`
+	Org     string         `json:"org"`
+	App     string         `json:"app"`
+	Name    string         `json:"name"`
+	Version string         `json:"version"`
+	ETag    string         `json:"etag"`
+}
+
+// SubscribeOptions configures Client.Subscribe.
+type SubscribeOptions struct {
+	// Prefetch, if true, fetches the updated prompt by ID as soon as its
+	// CacheEvent is delivered, so the cache is warm before any caller asks
+	// for it. Fetch failures are best-effort and do not prevent the event
+	// from being delivered on the channel.
+	Prefetch bool
+}
+
+// Subscribe opens a long-lived Server-Sent Events connection to
+// /api/v1/events and returns a channel that receives a CacheEvent whenever
+// any prompt visible to this API key is updated or deleted. Each event
+// transparently invalidates the matching "id:" and "name:" entries in the
+// Client cache (across all cached environments), so a subsequent Get or
+// GetByName is never served stale data from between polls.
+//
+// Subscribe reconnects transparently, resuming from the last delivered
+// event via the SSE Last-Event-ID header so no events are missed across a
+// reconnect. A failed connection attempt or a stream error is retried
+// after the same full-jitter exponential backoff as doRequestWithHeaders;
+// a stream the server ends cleanly is reopened at once. The channel is
+// closed only once ctx is canceled.
+func (c *Client) Subscribe(ctx context.Context, opts SubscribeOptions) (<-chan CacheEvent, error) {
+	events := make(chan CacheEvent)
+	go c.subscribeLoop(ctx, opts, events)
+	return events, nil
+}
+
+func (c *Client) subscribeLoop(ctx context.Context, opts SubscribeOptions, events chan<- CacheEvent) {
+	defer close(events)
+
+	var lastEventID string
+	attempt := 0
+	for ctx.Err() == nil {
+		headers := map[string]string{"Accept": "text/event-stream"}
+		if lastEventID != "" {
+			headers["Last-Event-ID"] = lastEventID
+		}
+
+		resp, err := c.doRequestWithHeaders(ctx, EndpointEvents, http.MethodGet, "/api/v1/events", nil, headers)
+		if err != nil {
+			if !sleepBeforeRetry(ctx, &attempt) {
+				return
+			}
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			if !sleepBeforeRetry(ctx, &attempt) {
+				return
+			}
+			continue
+		}
+
+		attempt = 0
+		id, streamErr := c.consumeEventStream(ctx, resp, opts, events)
+		if id != "" {
+			lastEventID = id
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if streamErr != nil {
+			if !sleepBeforeRetry(ctx, &attempt) {
+				return
+			}
+		}
+	}
+}
+
+// sleepBeforeRetry waits for the next backoff interval, returning false if
+// ctx is canceled while waiting.
+func sleepBeforeRetry(ctx context.Context, attempt *int) bool {
+	delay := backoffDelay(*attempt, 0)
+	*attempt++
+	return sleepCtx(ctx, delay) == nil
+}
+
+// consumeEventStream reads SSE frames off resp.Body, dispatching a
+// CacheEvent per frame and applying cache invalidation, until the stream
+// ends or ctx is canceled. It returns the last SSE event id observed (the
+// "id:" field), so the caller can resume from it via Last-Event-ID after a
+// reconnect.
+func (c *Client) consumeEventStream(ctx context.Context, resp *http.Response, opts SubscribeOptions, events chan<- CacheEvent) (lastEventID string, err error) {
+	defer resp.Body.Close()
+
+	type line struct {
+		text string
+		err  error
+	}
+	lines := make(chan line)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- line{text: scanner.Text()}
+		}
+		if scanErr := scanner.Err(); scanErr != nil {
+			lines <- line{err: scanErr}
+		}
+	}()
+
+	var data strings.Builder
+	for {
+		select {
+		case <-ctx.Done():
+			return lastEventID, nil
+		case l, ok := <-lines:
+			if !ok {
+				return lastEventID, nil
+			}
+			if l.err != nil {
+				return lastEventID, l.err
+			}
+			if l.text == "" {
+				if data.Len() == 0 {
+					continue
+				}
+				ev, parseErr := parseCacheEvent(data.String())
+				data.Reset()
+				if parseErr != nil {
+					continue
+				}
+				c.applyCacheEvent(ctx, ev, opts)
+				select {
+				case events <- ev:
+				case <-ctx.Done():
+					return lastEventID, nil
+				}
+				continue
+			}
+			if rest, ok := strings.CutPrefix(l.text, "id:"); ok {
+				lastEventID = strings.TrimPrefix(rest, " ")
+				continue
+			}
+			if rest, ok := strings.CutPrefix(l.text, "data:"); ok {
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(strings.TrimPrefix(rest, " "))
+			}
+		}
+	}
+}
+
+func parseCacheEvent(payload string) (CacheEvent, error) {
+	var ev CacheEvent
+	err := json.Unmarshal([]byte(payload), &ev)
+	return ev, err
+}
+
+// applyCacheEvent invalidates every cache entry that could hold a stale
+// copy of the affected prompt (its "id:" key plus its "name:" key across
+// every cached environment) and, if opts.Prefetch is set, re-fetches it so
+// the cache is warm again before any caller asks for it.
+func (c *Client) applyCacheEvent(ctx context.Context, ev CacheEvent, opts SubscribeOptions) {
+	c.cache.Invalidate("id:" + ev.ID)
+	c.cache.InvalidateByPrefix("name:" + ev.Org + "/" + ev.App + "/" + ev.Name + ":")
+
+	if opts.Prefetch && ev.Type != CacheEventDeleted && ev.ID != "" {
+		_, _ = c.Get(ctx, ev.ID)
+	}
+}
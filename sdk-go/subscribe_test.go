@@ -0,0 +1,130 @@
+package promptory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubscribe_UpdatedInvalidatesNameAndIDKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		writeSSEFrame(w, `{"type":"prompt.updated","id":"p1","org":"acme","app":"chat","name":"greeting","version":"2.0.0","etag":"v2"}`)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.cache.Set("id:p1", &Prompt{ID: "p1"}, "v1")
+	client.cache.Set("name:acme/chat/greeting:production", &Prompt{ID: "p1"}, "v1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Subscribe(ctx, SubscribeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != CacheEventUpdated {
+			t.Fatalf("Type = %q, want %q", ev.Type, CacheEventUpdated)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for cache event")
+	}
+
+	if cached, _ := client.cache.Get("id:p1"); cached != nil {
+		t.Error("expected id: cache entry to be invalidated")
+	}
+	if cached, _ := client.cache.Get("name:acme/chat/greeting:production"); cached != nil {
+		t.Error("expected name: cache entry to be invalidated")
+	}
+}
+
+func TestSubscribe_PrefetchRefetchesUpdatedPrompt(t *testing.T) {
+	var eventSent int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/events" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			writeSSEFrame(w, `{"type":"prompt.updated","id":"p1","org":"acme","app":"chat","name":"greeting"}`)
+			atomic.StoreInt32(&eventSent, 1)
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(samplePrompt)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Subscribe(ctx, SubscribeOptions{Prefetch: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-events:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for cache event")
+	}
+	if atomic.LoadInt32(&eventSent) == 0 {
+		t.Fatal("server never served the event stream")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cached, fresh := client.cache.Get("id:p1"); cached != nil && fresh {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("expected Prefetch to repopulate the cache for the updated prompt")
+}
+
+func TestSubscribe_ContextCancelClosesChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := client.Subscribe(ctx, SubscribeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected channel to close after context cancellation")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
@@ -0,0 +1,273 @@
+package promptory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WatchEventType identifies the kind of change reported on a watch stream.
+type WatchEventType string
+
+const (
+	// WatchEventUpdated indicates the prompt body or metadata changed.
+	WatchEventUpdated WatchEventType = "updated"
+
+	// WatchEventDeleted indicates the prompt was deleted.
+	WatchEventDeleted WatchEventType = "deleted"
+
+	// WatchEventEnvironmentChanged indicates the prompt's environment
+	// binding changed (e.g. a new version was promoted to production).
+	WatchEventEnvironmentChanged WatchEventType = "environment_changed"
+
+	// WatchEventCanceled is the final event on the channel. It is always
+	// delivered when the server closes the stream cleanly; when ctx is
+	// canceled it is delivered only if the receiver is ready to take it at
+	// that moment. Err is set if the stream ended due to an error.
+	WatchEventCanceled WatchEventType = "canceled"
+)
+
+// WatchEvent describes a single change delivered by Client.Watch or
+// Client.WatchByName. Prompt is nil for WatchEventDeleted and
+// WatchEventCanceled.
+type WatchEvent struct {
+	Type     WatchEventType
+	Prompt   *Prompt
+	Revision string
+	Err      error
+}
+
+// watchFrame is the JSON payload carried by each SSE "data:" frame.
+type watchFrame struct {
+	Type     string  `json:"type"`
+	Prompt   *Prompt `json:"prompt"`
+	Revision string  `json:"revision"`
+}
+
+// Watch opens a long-lived connection to the server and returns a channel
+// that receives a WatchEvent whenever the prompt identified by promptID is
+// updated, deleted, or has its environment binding changed. The channel is
+// closed once ctx is canceled or the server closes the stream cleanly,
+// after a final WatchEventCanceled event (see WatchEventCanceled).
+//
+// Watch transparently reconnects on transport errors using the same
+// exponential backoff as doRequestWithHeaders, resuming from the last-seen
+// revision via the X-Promptory-Revision header so no events are missed
+// across a reconnect. Each delivered event also updates the lruCache entry
+// for this prompt in place, so a subsequent Get skips the network entirely.
+func (c *Client) Watch(ctx context.Context, promptID string) (<-chan WatchEvent, error) {
+	path := "/api/v1/prompts/" + promptID + "/watch"
+	cacheKey := "id:" + promptID
+	return c.watch(ctx, path, cacheKey), nil
+}
+
+// WatchByName opens a long-lived connection for the prompt identified by its
+// fully qualified name (org/app/name). See Watch for streaming semantics.
+func (c *Client) WatchByName(ctx context.Context, org, app, name string, opts ...GetOption) (<-chan WatchEvent, error) {
+	o := &getOptions{}
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	envSuffix := "any"
+	pathSuffix := ""
+	if o.environment != "" {
+		envSuffix = o.environment
+		pathSuffix = "?environment=" + o.environment
+	}
+
+	path := fmt.Sprintf("/api/v1/prompts/by-name/%s/%s/%s/watch%s", org, app, name, pathSuffix)
+	cacheKey := fmt.Sprintf("name:%s/%s/%s:%s", org, app, name, envSuffix)
+	return c.watch(ctx, path, cacheKey), nil
+}
+
+func (c *Client) watch(ctx context.Context, path, cacheKey string) <-chan WatchEvent {
+	events := make(chan WatchEvent)
+	go c.watchLoop(ctx, path, cacheKey, events)
+	return events
+}
+
+// watchLoop owns the reconnect state machine: it issues the watch request,
+// streams frames until the connection drops, and reconnects with backoff
+// until ctx is canceled or the server closes the stream cleanly.
+func (c *Client) watchLoop(ctx context.Context, path, cacheKey string, events chan<- WatchEvent) {
+	defer close(events)
+
+	var revision string
+	attempt := 0
+	for ctx.Err() == nil {
+		headers := map[string]string{"Accept": "text/event-stream"}
+		if revision != "" {
+			headers["X-Promptory-Revision"] = revision
+		}
+
+		resp, err := c.doRequestWithHeaders(ctx, EndpointWatch, http.MethodGet, path, nil, headers)
+		if err != nil {
+			if !c.watchReconnectWait(ctx, &attempt, events, err) {
+				return
+			}
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			apiErr := c.handleError(resp)
+			resp.Body.Close()
+			if !c.watchReconnectWait(ctx, &attempt, events, apiErr) {
+				return
+			}
+			continue
+		}
+
+		attempt = 0
+		lastRevision, streamErr := c.consumeWatchStream(ctx, resp, cacheKey, events)
+		if lastRevision != "" {
+			revision = lastRevision
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if streamErr == nil {
+			// The server closed the stream cleanly: it has ended for good.
+			select {
+			case events <- WatchEvent{Type: WatchEventCanceled, Revision: revision}:
+			case <-ctx.Done():
+			}
+			return
+		}
+		if !c.watchReconnectWait(ctx, &attempt, events, streamErr) {
+			return
+		}
+	}
+
+	select {
+	case events <- WatchEvent{Type: WatchEventCanceled, Revision: revision}:
+	default:
+	}
+}
+
+// watchReconnectWait sleeps for the next backoff interval, returning false
+// (and emitting a final WatchEventCanceled) if ctx is canceled while
+// waiting.
+func (c *Client) watchReconnectWait(ctx context.Context, attempt *int, events chan<- WatchEvent, cause error) bool {
+	delay := backoffDelay(*attempt, 0)
+	*attempt++
+	if err := sleepCtx(ctx, delay); err != nil {
+		select {
+		case events <- WatchEvent{Type: WatchEventCanceled, Err: cause}:
+		default:
+		}
+		return false
+	}
+	return true
+}
+
+// consumeWatchStream reads SSE frames off resp.Body, dispatching a
+// WatchEvent per frame and keeping the lruCache in sync, until the stream
+// ends or ctx is canceled. It returns the last revision observed so the
+// caller can resume from it after a reconnect.
+func (c *Client) consumeWatchStream(ctx context.Context, resp *http.Response, cacheKey string, events chan<- WatchEvent) (revision string, err error) {
+	defer resp.Body.Close()
+
+	type line struct {
+		text string
+		err  error
+	}
+	lines := make(chan line)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- line{text: scanner.Text()}
+		}
+		if scanErr := scanner.Err(); scanErr != nil {
+			lines <- line{err: scanErr}
+		}
+	}()
+
+	var data strings.Builder
+	for {
+		select {
+		case <-ctx.Done():
+			return revision, nil
+		case l, ok := <-lines:
+			if !ok {
+				return revision, nil
+			}
+			if l.err != nil {
+				return revision, l.err
+			}
+			if l.text == "" {
+				if data.Len() == 0 {
+					continue
+				}
+				frame, parseErr := parseWatchFrame(data.String())
+				data.Reset()
+				if parseErr != nil {
+					continue
+				}
+				if frame.Revision != "" {
+					revision = frame.Revision
+				}
+				c.applyWatchFrame(cacheKey, frame)
+				select {
+				case events <- watchEventFromFrame(frame):
+				case <-ctx.Done():
+					return revision, nil
+				}
+				continue
+			}
+			if rest, ok := strings.CutPrefix(l.text, "data:"); ok {
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(strings.TrimPrefix(rest, " "))
+			}
+		}
+	}
+}
+
+func parseWatchFrame(payload string) (*watchFrame, error) {
+	var f watchFrame
+	if err := json.Unmarshal([]byte(payload), &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func watchEventFromFrame(f *watchFrame) WatchEvent {
+	return WatchEvent{
+		Type:     WatchEventType(f.Type),
+		Prompt:   f.Prompt,
+		Revision: f.Revision,
+	}
+}
+
+// applyWatchFrame updates the lruCache entry in place so subsequent Get
+// calls observe the change without a network round trip. The entry is
+// cached with no ETag: f.Revision is a stream cursor for resuming Watch
+// after a reconnect (via X-Promptory-Revision), not the server's real
+// HTTP ETag, so it must not be used as one — doing so would make a later
+// conditional Get send an If-None-Match that can never match, silently
+// defeating 304 revalidation until the entry naturally expires and is
+// refetched in full.
+//
+// When c.verifyKeys is set, f.Prompt is verified exactly as doFetch
+// verifies a single-prompt fetch before it's cached; a frame that fails
+// verification (or carries no Signature at all) is dropped instead of
+// poisoning the cache for a later Get to trust unchecked. The event is
+// still delivered to the caller on events either way - only the cache
+// write is gated.
+func (c *Client) applyWatchFrame(cacheKey string, f *watchFrame) {
+	switch WatchEventType(f.Type) {
+	case WatchEventUpdated, WatchEventEnvironmentChanged:
+		if f.Prompt != nil && c.verifyFetchedPrompt(f.Prompt) == nil {
+			c.cache.Set(cacheKey, f.Prompt, "")
+		}
+	case WatchEventDeleted:
+		c.cache.Invalidate(cacheKey)
+	}
+}
@@ -0,0 +1,174 @@
+package promptory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func writeSSEFrame(w http.ResponseWriter, payload string) {
+	fmt.Fprintf(w, "data: %s\n\n", payload)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func TestWatch_DeliversUpdateAndUpdatesCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		writeSSEFrame(w, `{"type":"updated","revision":"2","prompt":{"id":"p1","name":"greeting","body":"hi"}}`)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Watch(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != WatchEventUpdated {
+			t.Fatalf("Type = %q, want %q", ev.Type, WatchEventUpdated)
+		}
+		if ev.Prompt == nil || ev.Prompt.Name != "greeting" {
+			t.Fatalf("unexpected prompt in event: %+v", ev.Prompt)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	cached, fresh := client.cache.Get("id:p1")
+	if cached == nil || !fresh {
+		t.Fatal("expected Watch to populate the cache")
+	}
+	if cached.Value.Body != "hi" {
+		t.Errorf("cached body = %q, want %q", cached.Value.Body, "hi")
+	}
+	if cached.ETag != "" {
+		t.Errorf("cached ETag = %q, want empty (a watch frame's revision is not a real ETag)", cached.ETag)
+	}
+}
+
+func TestWatch_DoesNotCacheUnverifiedFrameWhenVerifyKeysSet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		writeSSEFrame(w, `{"type":"updated","revision":"2","prompt":{"id":"p1","name":"greeting","body":"hi"}}`)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:    server.URL,
+		APIKey:     "pm_test_key",
+		VerifyKeys: NewStaticKeySet(nil),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Watch(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != WatchEventUpdated {
+			t.Fatalf("Type = %q, want %q", ev.Type, WatchEventUpdated)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	if cached, _ := client.cache.Get("id:p1"); cached != nil {
+		t.Error("expected an unsigned frame not to be cached when VerifyKeys is set")
+	}
+}
+
+func TestWatch_DeletedInvalidatesCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		writeSSEFrame(w, `{"type":"deleted","revision":"3"}`)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.cache.Set("id:p1", &Prompt{ID: "p1"}, "etag1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Watch(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != WatchEventDeleted {
+			t.Fatalf("Type = %q, want %q", ev.Type, WatchEventDeleted)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	if cached, _ := client.cache.Get("id:p1"); cached != nil {
+		t.Error("expected cache entry to be invalidated on delete")
+	}
+}
+
+func TestWatch_ContextCancelClosesChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, APIKey: "pm_test_key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := client.Watch(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			// a final Canceled event may arrive before the channel closes
+			_, ok = <-events
+			if ok {
+				t.Fatal("expected channel to close after context cancellation")
+			}
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
@@ -0,0 +1,68 @@
+package promptory
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// webhookSignatureHeader carries the hex-encoded HMAC-SHA256 signature of
+// the raw request body, keyed by ClientOptions.WebhookSecret.
+const webhookSignatureHeader = "X-Promptory-Signature"
+
+// maxWebhookBodyBytes bounds how much of a webhook request body is read,
+// so a misbehaving sender can't exhaust memory.
+const maxWebhookBodyBytes = 1 << 20 // 1 MiB
+
+// HandleWebhook verifies and applies a single CacheEvent pushed by a
+// Promptory webhook: the same invalidation Client.Subscribe performs, for
+// callers behind a firewall who can't hold open a long-lived connection.
+// Configure the same secret the server signs with via
+// ClientOptions.WebhookSecret; a request with a missing or invalid
+// X-Promptory-Signature is rejected with 401 and never reaches the cache.
+//
+// Register it directly as an http.HandlerFunc, e.g.:
+//
+//	http.HandleFunc("/promptory/webhook", client.HandleWebhook)
+func (c *Client) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	if c.webhookSecret == "" {
+		http.Error(w, "promptory: webhook handling is not configured (ClientOptions.WebhookSecret is empty)", http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		http.Error(w, "promptory: failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if !c.verifyWebhookSignature(body, r.Header.Get(webhookSignatureHeader)) {
+		http.Error(w, "promptory: invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	var ev CacheEvent
+	if err := json.Unmarshal(body, &ev); err != nil {
+		http.Error(w, "promptory: malformed event payload", http.StatusBadRequest)
+		return
+	}
+
+	c.applyCacheEvent(r.Context(), ev, SubscribeOptions{})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyWebhookSignature reports whether signature is the hex-encoded
+// HMAC-SHA256 of body under c.webhookSecret, using a constant-time
+// comparison to avoid leaking timing information about the expected value.
+func (c *Client) verifyWebhookSignature(body []byte, signature string) bool {
+	if signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(c.webhookSecret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,96 @@
+package promptory
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newWebhookClient(t *testing.T, secret string) *Client {
+	t.Helper()
+	client, err := NewClient(ClientOptions{
+		BaseURL:       "https://example.invalid",
+		APIKey:        "pm_test_key",
+		WebhookSecret: secret,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestHandleWebhook_ValidSignatureInvalidatesCache(t *testing.T) {
+	client := newWebhookClient(t, "whsec_test")
+	client.cache.Set("id:p1", &Prompt{ID: "p1"}, "v1")
+
+	body := []byte(`{"type":"prompt.deleted","id":"p1","org":"acme","app":"chat","name":"greeting"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set(webhookSignatureHeader, signWebhookBody("whsec_test", body))
+	rec := httptest.NewRecorder()
+
+	client.HandleWebhook(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d (body: %s)", rec.Code, http.StatusNoContent, rec.Body.String())
+	}
+	if cached, _ := client.cache.Get("id:p1"); cached != nil {
+		t.Error("expected cache entry to be invalidated")
+	}
+}
+
+func TestHandleWebhook_InvalidSignatureRejected(t *testing.T) {
+	client := newWebhookClient(t, "whsec_test")
+	client.cache.Set("id:p1", &Prompt{ID: "p1"}, "v1")
+
+	body := []byte(`{"type":"prompt.deleted","id":"p1","org":"acme","app":"chat","name":"greeting"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set(webhookSignatureHeader, signWebhookBody("wrong-secret", body))
+	rec := httptest.NewRecorder()
+
+	client.HandleWebhook(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if cached, _ := client.cache.Get("id:p1"); cached == nil {
+		t.Error("expected cache entry to survive a rejected webhook")
+	}
+}
+
+func TestHandleWebhook_MissingSignatureRejected(t *testing.T) {
+	client := newWebhookClient(t, "whsec_test")
+
+	body := []byte(`{"type":"prompt.deleted","id":"p1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	client.HandleWebhook(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestHandleWebhook_NotConfiguredRejected(t *testing.T) {
+	client := newWebhookClient(t, "")
+
+	body := []byte(`{"type":"prompt.deleted","id":"p1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	client.HandleWebhook(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}